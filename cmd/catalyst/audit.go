@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/audit"
+	"github.com/PraveenGongada/catalyst/internal/config"
+)
+
+// auditFilter selects the records of the audit log to list or export.
+type auditFilter struct {
+	since      time.Time
+	until      time.Time
+	repository string
+	workflow   string
+	ref        string
+	user       string
+	outcome    string
+}
+
+func handleAuditCommand(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	configPath := fs.String(
+		"config",
+		"",
+		"Path to the configuration file (default: $CATALYST_CONFIG or ./catalyst.yaml)",
+	)
+	file := fs.String("file", "", "Audit log to read instead of the one of the configuration")
+	since := fs.String("since", "", "Only records at or after this time (e.g. 24h, 7d, 2025-01-31)")
+	until := fs.String("until", "", "Only records before this time (e.g. 24h, 7d, 2025-01-31)")
+	repository := fs.String("repo", "", "Only records of this repository")
+	workflow := fs.String("workflow", "", "Only records of this workflow key or file")
+	ref := fs.String("ref", "", "Only records dispatched on this ref")
+	user := fs.String("user", "", "Only records of this local user, git name or git email")
+	outcome := fs.String("outcome", "", "Only records with this outcome (dispatched|retried|failed)")
+	limit := fs.Int("limit", 0, "Maximum number of records, most recent first kept (0 for all)")
+	outputFormat := fs.String("format", "table", "Output format (table|csv|json)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch *outputFormat {
+	case "table", "csv", "json":
+	default:
+		return fmt.Errorf("invalid format '%s'. Supported formats: table, csv, json", *outputFormat)
+	}
+
+	switch *outcome {
+	case "", audit.OutcomeDispatched, audit.OutcomeRetried, audit.OutcomeFailed:
+	default:
+		return fmt.Errorf("invalid outcome '%s'. Supported outcomes: %s, %s, %s",
+			*outcome, audit.OutcomeDispatched, audit.OutcomeRetried, audit.OutcomeFailed)
+	}
+
+	now := time.Now()
+	filter := auditFilter{
+		repository: *repository,
+		workflow:   *workflow,
+		ref:        *ref,
+		user:       *user,
+		outcome:    *outcome,
+	}
+
+	var err error
+	if filter.since, err = parseAuditTime(*since, now); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if filter.until, err = parseAuditTime(*until, now); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	path := *file
+	if path == "" {
+		cfg, err := config.Load(*configPath)
+		if err != nil {
+			return fmt.Errorf("error loading configuration (pass --file to read an audit log directly): %w", err)
+		}
+		if path, err = audit.Path(cfg); err != nil {
+			return err
+		}
+	}
+
+	records, err := audit.Read(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	var selected []audit.Record
+	for _, record := range records {
+		if filter.matches(record) {
+			selected = append(selected, record)
+		}
+	}
+
+	if *limit > 0 && len(selected) > *limit {
+		selected = selected[len(selected)-*limit:]
+	}
+
+	switch *outputFormat {
+	case "csv":
+		return writeAuditCSV(selected)
+	case "json":
+		return writeAuditJSON(selected)
+	}
+
+	if len(selected) == 0 {
+		fmt.Fprintf(os.Stderr, "No matching audit records found in %s\n", path)
+		return nil
+	}
+	return writeAuditTable(selected)
+}
+
+// parseAuditTime accepts a duration before now (24h, 90m, 7d), a date or
+// an RFC 3339 timestamp. An empty value is the zero time.
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not a duration (24h, 7d), date (2006-01-02) or RFC 3339 timestamp", value)
+}
+
+func (f auditFilter) matches(record audit.Record) bool {
+	switch {
+	case !f.since.IsZero() && record.Timestamp.Before(f.since):
+		return false
+	case !f.until.IsZero() && !record.Timestamp.Before(f.until):
+		return false
+	case f.repository != "" && !strings.EqualFold(record.Repository, f.repository):
+		return false
+	case f.workflow != "" && record.Workflow != f.workflow && record.File != f.workflow:
+		return false
+	case f.ref != "" && record.Ref != f.ref:
+		return false
+	case f.outcome != "" && record.Outcome != f.outcome:
+		return false
+	case f.user != "" && !strings.EqualFold(record.User, f.user) &&
+		!strings.EqualFold(record.GitName, f.user) && !strings.EqualFold(record.GitEmail, f.user):
+		return false
+	}
+	return true
+}
+
+func writeAuditTable(records []audit.Record) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tUSER\tREPOSITORY\tWORKFLOW\tREF\tATTEMPT\tOUTCOME\tERROR")
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			record.Timestamp.Local().Format("2006-01-02 15:04:05"),
+			record.User,
+			record.Repository,
+			auditWorkflow(record),
+			record.Ref,
+			record.Attempt,
+			record.Outcome,
+			record.Error,
+		)
+	}
+
+	return w.Flush()
+}
+
+func auditWorkflow(record audit.Record) string {
+	if record.Part != "" {
+		return record.Workflow + " (" + record.Part + ")"
+	}
+	return record.Workflow
+}
+
+func writeAuditCSV(records []audit.Record) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{
+		"timestamp", "user", "git_name", "git_email", "repository", "workflow", "file",
+		"ref", "part", "attempt", "matrix_hash", "inputs", "input_values", "outcome", "error",
+	})
+
+	for _, record := range records {
+		inputs, err := json.Marshal(record.Inputs)
+		if err != nil {
+			return fmt.Errorf("error marshaling audit inputs: %w", err)
+		}
+
+		inputValues, err := json.Marshal(record.InputValues)
+		if err != nil {
+			return fmt.Errorf("error marshaling audit input values: %w", err)
+		}
+
+		w.Write([]string{
+			record.Timestamp.Format(time.RFC3339),
+			record.User,
+			record.GitName,
+			record.GitEmail,
+			record.Repository,
+			record.Workflow,
+			record.File,
+			record.Ref,
+			record.Part,
+			strconv.Itoa(record.Attempt),
+			record.MatrixHash,
+			string(inputs),
+			string(inputValues),
+			record.Outcome,
+			record.Error,
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func writeAuditJSON(records []audit.Record) error {
+	if records == nil {
+		records = []audit.Record{}
+	}
+
+	output, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling audit records: %w", err)
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/history"
+	"github.com/PraveenGongada/catalyst/internal/tui"
+)
+
+func handleHistoryCommand(args []string) error {
+	action := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action = args[0]
+		args = args[1:]
+	}
+
+	fs := flag.NewFlagSet("history "+action, flag.ExitOnError)
+	configPath := fs.String(
+		"config",
+		"",
+		"Path to the configuration file (default: $CATALYST_CONFIG or ./catalyst.yaml)",
+	)
+	limit := fs.Int("limit", 20, "Maximum number of entries to list (0 for all)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Only the deployments of the configured repository are listed.
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	store, err := history.DefaultStore(cfg.GitHub.Repository)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		return listHistory(store, *limit)
+
+	case "show":
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: catalyst history show <id|last>")
+		}
+		return showHistory(store, fs.Arg(0))
+
+	case "rerun":
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: catalyst history rerun <id|last>")
+		}
+		return tui.Start(*configPath, tui.Options{HistoryID: fs.Arg(0)})
+
+	default:
+		return fmt.Errorf("unknown history command '%s'. Available commands: list, show, rerun", action)
+	}
+}
+
+func listHistory(store *history.Store, limit int) error {
+	entries, warnings, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %v\n", warning)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "No deployment history of %s found in %s\n", store.Repository, store.Dir)
+		return nil
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tAPPS\tPLATFORMS\tENVIRONMENTS\tBRANCH\tWORKFLOWS")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			entry.ID,
+			entry.Timestamp.Local().Format("2006-01-02 15:04"),
+			strings.Join(entry.Apps, ","),
+			strings.Join(entry.Platforms, ","),
+			strings.Join(entry.Environments, ","),
+			entry.Branch,
+			len(entry.Workflows),
+		)
+	}
+
+	return w.Flush()
+}
+
+func showHistory(store *history.Store, id string) error {
+	entry, err := store.Get(id)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling history entry: %w", err)
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
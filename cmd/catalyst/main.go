@@ -20,9 +20,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/PraveenGongada/catalyst/internal/config"
 	"github.com/PraveenGongada/catalyst/internal/extractor"
+	"github.com/PraveenGongada/catalyst/internal/history"
+	"github.com/PraveenGongada/catalyst/internal/inputsource"
+	"github.com/PraveenGongada/catalyst/internal/matrix"
 	"github.com/PraveenGongada/catalyst/internal/tui"
 )
 
@@ -33,6 +38,35 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "trigger":
+			if err := handleTriggerCommand(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error triggering workflows: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "validate":
+			if err := handleValidateCommand(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error validating configuration: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "history":
+			if err := handleHistoryCommand(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading deployment history: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "audit":
+			if err := handleAuditCommand(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading audit log: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	configPath := flag.String(
 		"config",
 		"",
@@ -54,7 +88,40 @@ func main() {
 	outputFormat := flag.String(
 		"format",
 		"json",
-		"Output format for extracted matrices (json|yaml)",
+		"Output format for extracted matrices (json|yaml), or dispatch for the dispatch requests",
+	)
+
+	var extract extractSelection
+	flag.Var(&extract.apps, "app", "App to extract matrices for (repeatable, default: all)")
+	flag.Var(&extract.platforms, "platform", "Platform to extract matrices for (repeatable, default: all)")
+	flag.Var(&extract.environments, "env", "Environment to extract matrices for (repeatable, default: all)")
+	flag.Var(&extract.inputs, "input", "Input value as key=value for extracted matrices (repeatable)")
+	flag.StringVar(&extract.branch, "branch", "main", "Branch for {{git.*}} placeholders in extracted matrices")
+	flag.StringVar(&extract.changeLog, "changelog", "", "Changelog input of extracted dispatch requests")
+	flag.BoolVar(&extract.sources, "sources", false, "Compute extracted inputs without a value from their source")
+
+	rerunID := flag.String(
+		"rerun",
+		"",
+		"Preload a previous deployment from history (entry id or \"last\")",
+	)
+
+	presetName := flag.String(
+		"preset",
+		"",
+		"Start with the selections of a preset from the configuration",
+	)
+
+	dryRun := flag.Bool(
+		"dry-run",
+		false,
+		"Write the dispatch payloads to disk instead of contacting GitHub",
+	)
+
+	outputDir := flag.String(
+		"output-dir",
+		"",
+		"Directory for dry-run payloads (default: ./catalyst-payloads-<timestamp>)",
 	)
 
 	flag.Parse()
@@ -65,20 +132,47 @@ func main() {
 	}
 
 	if *extractWorkflow != "" {
-		if err := handleExtractCommand(*configPath, *extractWorkflow, *outputFormat); err != nil {
+		if err := handleExtractCommand(*configPath, *extractWorkflow, *outputFormat, extract); err != nil {
 			fmt.Fprintf(os.Stderr, "Error extracting matrices: %v\n", err)
 			os.Exit(1)
 		}
 		return
 	}
 
-	if err := tui.Start(*configPath); err != nil {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "app", "platform", "env", "input", "branch", "changelog", "sources":
+			fmt.Fprintf(os.Stderr, "Error: -%s can only be used with -extract\n", f.Name)
+			os.Exit(1)
+		}
+	})
+
+	if err := tui.Start(*configPath, tui.Options{
+		HistoryID: *rerunID,
+		Preset:    *presetName,
+		DryRun:    *dryRun,
+		OutputDir: *outputDir,
+	}); err != nil {
 		fmt.Fprintf(os.Stderr, "Error starting Catalyst: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func handleExtractCommand(configPath, workflowKey, format string) error {
+// extractSelection narrows the combinations matrices are extracted for, like
+// the selections of a deployment.
+type extractSelection struct {
+	apps         stringSliceFlag
+	platforms    stringSliceFlag
+	environments stringSliceFlag
+	inputs       stringSliceFlag
+	branch       string
+	changeLog    string
+	// sources computes inputs from their source (git tags, history) like a
+	// deployment does, instead of using their defaults.
+	sources bool
+}
+
+func handleExtractCommand(configPath, workflowKey, format string, selection extractSelection) error {
 	cfg, err := config.Load(configPath)
 	if err != nil {
 		return fmt.Errorf("error loading configuration: %w", err)
@@ -88,8 +182,8 @@ func handleExtractCommand(configPath, workflowKey, format string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	if format != "json" && format != "yaml" {
-		return fmt.Errorf("invalid format '%s'. Supported formats: json, yaml", format)
+	if format != "json" && format != "yaml" && format != "dispatch" {
+		return fmt.Errorf("invalid format '%s'. Supported formats: json, yaml, dispatch", format)
 	}
 
 	if _, exists := cfg.GitHub.Workflows[workflowKey]; !exists {
@@ -97,24 +191,88 @@ func handleExtractCommand(configPath, workflowKey, format string) error {
 			workflowKey, getAvailableWorkflows(cfg))
 	}
 
-	extractedMatrices, err := extractor.ExtractWorkflowMatrices(cfg, workflowKey)
+	apps, err := extractSelections("app", selection.apps, cfg.GetApps())
 	if err != nil {
-		return fmt.Errorf("error extracting matrices: %w", err)
+		return err
 	}
 
+	platforms, err := extractSelections("platform", selection.platforms, cfg.GetPlatforms(apps))
+	if err != nil {
+		return err
+	}
+
+	environments, err := extractSelections(
+		"environment",
+		selection.environments,
+		cfg.GetEnvironments(apps, platforms),
+	)
+	if err != nil {
+		return err
+	}
+
+	generator := matrix.NewGenerator(cfg)
+	generator.SetSelectedApps(apps)
+	generator.SetSelectedPlatforms(platforms)
+	generator.SetSelectedEnvironments(environments)
+
+	if err := setInputs(cfg, generator, selection.inputs); err != nil {
+		return err
+	}
+
+	var resolver *inputsource.Resolver
+	if selection.sources {
+		store, _ := history.DefaultStore(cfg.GitHub.Repository)
+		resolver = inputsource.NewResolver(cfg, store, apps, platforms)
+	}
+	if err := resolveInputs(cfg, generator, resolver); err != nil {
+		return err
+	}
+
+	if err := generator.SetBranch(strings.TrimSpace(selection.branch)); err != nil {
+		return err
+	}
+
+	if err := generator.CheckEnv(); err != nil {
+		return err
+	}
+
+	extractedMatrices := extractor.ExtractWorkflowMatrices(generator, workflowKey)
 	if len(extractedMatrices) == 0 {
 		fmt.Fprintf(os.Stderr, "Warning: No matrices found for workflow '%s'\n", workflowKey)
 	}
 
-	output, err := extractor.FormatOutput(extractedMatrices, format)
-	if err != nil {
-		return fmt.Errorf("error formatting output: %w", err)
+	var output string
+	if format == "dispatch" {
+		dispatches, err := extractor.ExtractDispatches(generator, workflowKey, strings.TrimSpace(selection.changeLog))
+		if err != nil {
+			return fmt.Errorf("error extracting dispatches: %w", err)
+		}
+		output, err = extractor.FormatDispatches(dispatches)
+		if err != nil {
+			return fmt.Errorf("error formatting output: %w", err)
+		}
+	} else {
+		output, err = extractor.FormatOutput(extractedMatrices, format)
+		if err != nil {
+			return fmt.Errorf("error formatting output: %w", err)
+		}
 	}
 
 	fmt.Print(output)
 	return nil
 }
 
+// extractSelections resolves the selected names like a deployment does, or
+// selects everything available when nothing is selected.
+func extractSelections(kind string, selected []string, available []string) ([]string, error) {
+	if len(selected) == 0 {
+		sorted := append([]string{}, available...)
+		sort.Strings(sorted)
+		return sorted, nil
+	}
+	return resolveSelections(kind, selected, available)
+}
+
 func getAvailableWorkflows(cfg *config.Config) []string {
 	var workflows []string
 	for key := range cfg.GitHub.Workflows {
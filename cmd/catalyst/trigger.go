@@ -0,0 +1,438 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/PraveenGongada/catalyst/internal/audit"
+	"github.com/PraveenGongada/catalyst/internal/changelog"
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
+	"github.com/PraveenGongada/catalyst/internal/github"
+	"github.com/PraveenGongada/catalyst/internal/history"
+	"github.com/PraveenGongada/catalyst/internal/inputsource"
+	"github.com/PraveenGongada/catalyst/internal/matrix"
+	"github.com/PraveenGongada/catalyst/internal/notify"
+	"github.com/PraveenGongada/catalyst/internal/refs"
+)
+
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func handleTriggerCommand(args []string) error {
+	fs := flag.NewFlagSet("trigger", flag.ExitOnError)
+
+	configPath := fs.String(
+		"config",
+		"",
+		"Path to the configuration file (default: $CATALYST_CONFIG or ./catalyst.yaml)",
+	)
+
+	var apps, platforms, environments, inputs stringSliceFlag
+	fs.Var(&apps, "app", "App to deploy (repeatable)")
+	fs.Var(&platforms, "platform", "Platform to deploy (repeatable)")
+	fs.Var(&environments, "env", "Environment to deploy (repeatable)")
+	fs.Var(&inputs, "input", "Input value as key=value (repeatable)")
+
+	presetName := fs.String("preset", "", "Preset to take apps, platforms, environments and inputs from")
+	branchName := fs.String("branch", "main", "Branch to trigger workflows on")
+	changeLog := fs.String("changelog", "", "Changelog for this deployment")
+	changeLogFile := fs.String("changelog-file", "", "Read the changelog from a file")
+	changeLogFromGit := fs.String(
+		"changelog-from-git",
+		"",
+		"Generate the changelog from the git history since this ref (\"last-tag\" for the latest tag)",
+	)
+	dryRun := fs.Bool("dry-run", false, "Write the dispatch payloads instead of contacting GitHub")
+	freezeOverride := fs.String(
+		"freeze-override",
+		"",
+		"Deploy during a deployment freeze that allows overrides, giving the reason",
+	)
+	confirmProtected := fs.Bool(
+		"yes-i-mean-production",
+		false,
+		"Confirm a deployment that includes protected environments",
+	)
+	outputDir := fs.String(
+		"output-dir",
+		"",
+		"Directory for dry-run payloads, or - for stdout (default: ./catalyst-payloads-<timestamp>)",
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	var presetInputs map[string]string
+	if *presetName != "" {
+		preset, err := cfg.ResolvePreset(*presetName)
+		if err != nil {
+			return err
+		}
+
+		if len(apps) == 0 {
+			apps = preset.Apps
+		}
+		if len(platforms) == 0 {
+			platforms = preset.Platforms
+		}
+		if len(environments) == 0 {
+			environments = preset.Environments
+		}
+		presetInputs = preset.Inputs
+	}
+
+	if len(apps) == 0 || len(platforms) == 0 || len(environments) == 0 {
+		return fmt.Errorf("at least one --app, --platform and --env is required (directly or via --preset)")
+	}
+
+	selectedApps, err := resolveSelections("app", apps, cfg.GetApps())
+	if err != nil {
+		return err
+	}
+
+	selectedPlatforms, err := resolveSelections(
+		"platform",
+		platforms,
+		cfg.GetPlatforms(selectedApps),
+	)
+	if err != nil {
+		return err
+	}
+
+	selectedEnvironments, err := resolveSelections(
+		"environment",
+		environments,
+		cfg.GetEnvironments(selectedApps, selectedPlatforms),
+	)
+	if err != nil {
+		return err
+	}
+
+	generator := matrix.NewGenerator(cfg)
+	generator.SetSelectedApps(selectedApps)
+	generator.SetSelectedPlatforms(selectedPlatforms)
+	generator.SetSelectedEnvironments(selectedEnvironments)
+
+	for key, value := range presetInputs {
+		generator.SetInputValue(key, value)
+	}
+
+	if err := setInputs(cfg, generator, inputs); err != nil {
+		return err
+	}
+
+	store, _ := history.DefaultStore(cfg.GitHub.Repository)
+	if err := resolveInputs(cfg, generator, inputsource.NewResolver(cfg, store, selectedApps, selectedPlatforms)); err != nil {
+		return err
+	}
+
+	if *changeLogFile != "" {
+		data, err := os.ReadFile(*changeLogFile)
+		if err != nil {
+			return fmt.Errorf("failed to read changelog file %s: %w", *changeLogFile, err)
+		}
+		*changeLog = string(data)
+	}
+
+	if *changeLogFromGit != "" {
+		if *changeLog != "" {
+			return fmt.Errorf("--changelog-from-git cannot be combined with --changelog or --changelog-file")
+		}
+
+		opts := changelog.OptionsFor(cfg, selectedApps, *changeLogFromGit, strings.TrimSpace(*branchName))
+		generated, err := changelog.Generate(opts)
+		if err != nil {
+			return fmt.Errorf("failed to generate changelog: %w", err)
+		}
+		*changeLog = generated
+	}
+
+	if strings.TrimSpace(*changeLog) == "" {
+		return fmt.Errorf("changelog is required (use --changelog, --changelog-file or --changelog-from-git)")
+	}
+
+	if strings.TrimSpace(*branchName) == "" {
+		return fmt.Errorf("branch name is required")
+	}
+
+	if err := generator.SetBranch(strings.TrimSpace(*branchName)); err != nil {
+		return err
+	}
+
+	if err := generator.CheckRefs(strings.TrimSpace(*branchName)); err != nil {
+		return err
+	}
+
+	if err := generator.CheckEnv(); err != nil {
+		return err
+	}
+
+	purifiedMatrices := generator.GroupedMatricesPurified()
+
+	if dispatch.TotalMatrices(purifiedMatrices) == 0 {
+		return fmt.Errorf("no matrices generated from your selections")
+	}
+
+	if err := dispatch.CheckChunks(cfg, purifiedMatrices); err != nil {
+		return err
+	}
+
+	if protected := generator.ProtectedEnvironments(); len(protected) > 0 && !*dryRun && !*confirmProtected {
+		return fmt.Errorf(
+			"selection includes protected environments (%s); pass --yes-i-mean-production to deploy",
+			strings.Join(protected, ", "),
+		)
+	}
+
+	freezes := generator.ActiveFreezes()
+	if err := config.CheckFreezes(freezes, *freezeOverride); err != nil && !*dryRun {
+		if config.Overridable(freezes) {
+			return fmt.Errorf("%w; pass it with --freeze-override", err)
+		}
+		return err
+	}
+
+	// The values of {{env.*}} placeholders may be secrets, so they are
+	// redacted from everything written to disk.
+	redactedMatrices := generator.RedactedMatrices()
+
+	var client github.Client
+	var auditLog *audit.Log
+	if *dryRun {
+		client = github.NewDryRunClient(*outputDir)
+	} else {
+		client, err = github.NewClient(cfg.GitHub)
+		if err != nil {
+			return err
+		}
+
+		auditLog, err = audit.Open(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: audit log unavailable: %v\n", err)
+		}
+	}
+
+	// Dry runs have no API to list the refs with and dispatch nothing, so
+	// the branch is only checked for real dispatches.
+	if !*dryRun {
+		targets := refs.LoadTargets(cfg, client, generator.BranchRepositories())
+		warnings, err := targets.Check(strings.TrimSpace(*branchName))
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	dispatchedChangeLog := config.FreezeOverrideNote(strings.TrimSpace(*changeLog), freezes, *freezeOverride)
+
+	results := dispatch.Run(dispatch.Request{
+		Config:       cfg,
+		Client:       client,
+		Matrices:     purifiedMatrices,
+		Branch:       strings.TrimSpace(*branchName),
+		Placeholders: generator.PlaceholderContext(),
+		ChangeLog:    dispatchedChangeLog,
+		Redacted:     redactedMatrices,
+		Audit:        auditLog,
+	})
+
+	printTriggerReport(results, *dryRun)
+
+	if err := auditLog.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record dispatches in the audit log: %v\n", err)
+	}
+
+	if dryRunClient, ok := client.(*github.DryRunClient); ok {
+		if dryRunClient.OutputDir != github.StdoutOutput {
+			fmt.Fprintf(os.Stderr, "Dry run: payloads written to %s\n", dryRunClient.OutputDir)
+		}
+	} else if len(dispatch.Failed(results)) < len(results) {
+		inputValues := make(map[string]string)
+		for key, input := range cfg.Inputs {
+			inputValues[key] = input.Default
+		}
+		for key, value := range generator.InputValues {
+			inputValues[key] = value
+		}
+
+		entry := history.NewEntry(
+			cfg.GitHub.Repository,
+			selectedApps,
+			selectedPlatforms,
+			selectedEnvironments,
+			inputValues,
+			strings.TrimSpace(*branchName),
+			strings.TrimSpace(*changeLog),
+			redactedMatrices,
+			results,
+		)
+
+		if store != nil {
+			if err := store.Save(entry); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to record deployment history: %v\n", err)
+			}
+		}
+
+		message := notify.NewMessage(
+			cfg,
+			selectedApps,
+			selectedPlatforms,
+			selectedEnvironments,
+			strings.TrimSpace(*branchName),
+			dispatchedChangeLog,
+			results,
+		)
+		if err := notify.Send(cfg, message); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+		}
+	}
+
+	if failed := dispatch.Failed(results); len(failed) > 0 {
+		return fmt.Errorf("%d of %d workflow dispatches failed", len(failed), len(results))
+	}
+
+	return nil
+}
+
+func resolveSelections(kind string, selected []string, available []string) ([]string, error) {
+	availableMap := make(map[string]string)
+	for _, item := range available {
+		availableMap[strings.ToLower(item)] = item
+	}
+
+	resolved := make([]string, 0, len(selected))
+	for _, item := range selected {
+		actual, ok := availableMap[strings.ToLower(strings.TrimSpace(item))]
+		if !ok {
+			return nil, fmt.Errorf("unknown %s '%s'. Available: %v", kind, item, available)
+		}
+		resolved = append(resolved, actual)
+	}
+
+	return resolved, nil
+}
+
+// setInputs applies input values given as key=value to the generator.
+func setInputs(cfg *config.Config, generator *matrix.Generator, inputs []string) error {
+	for _, input := range inputs {
+		key, value, found := strings.Cut(input, "=")
+		if !found {
+			return fmt.Errorf("invalid input '%s', expected key=value", input)
+		}
+
+		key = strings.TrimSpace(key)
+		if _, ok := cfg.Inputs[key]; !ok {
+			return fmt.Errorf("unknown input '%s'", key)
+		}
+		generator.SetInputValue(key, strings.TrimSpace(value))
+	}
+	return nil
+}
+
+// resolveInputs computes the inputs referenced by the selections that have
+// no value but a source, and validates the value of every referenced input.
+// Without a resolver, such inputs take their default.
+func resolveInputs(cfg *config.Config, generator *matrix.Generator, resolver *inputsource.Resolver) error {
+	for _, key := range generator.ReferencedInputs() {
+		inputConfig, ok := cfg.Inputs[key]
+		if !ok {
+			continue
+		}
+
+		value, ok := generator.InputValues[key]
+		if !ok {
+			value = inputConfig.Default
+		}
+
+		if !ok && inputConfig.Source.Type != "" && resolver != nil {
+			sourced, err := resolver.Resolve(key)
+			switch {
+			case err == nil:
+				value = sourced
+				generator.SetInputValue(key, value)
+			case inputConfig.Default != "":
+				fmt.Fprintf(os.Stderr, "Warning: could not compute input '%s' from %s, "+
+					"using default: %v\n", key, inputConfig.Source.Type, err)
+			default:
+				return fmt.Errorf("could not compute input '%s' from %s: %w",
+					key, inputConfig.Source.Type, err)
+			}
+		}
+		if err := inputConfig.ValidateValue(value); err != nil {
+			return fmt.Errorf("input '%s' %w", key, err)
+		}
+	}
+	return nil
+}
+
+func printTriggerReport(results []dispatch.Result, dryRun bool) {
+	action := "triggered"
+	if dryRun {
+		action = "written (dry run)"
+	}
+
+	for _, result := range results {
+		target := fmt.Sprintf("%s in %s@%s", result.File, result.Repository, result.Branch)
+		if part := result.Part(); part != "" {
+			target += ", " + part
+		}
+		if result.Attempts > 1 {
+			target += fmt.Sprintf(", %d attempts", result.Attempts)
+		}
+
+		if result.Error != nil {
+			fmt.Fprintf(os.Stderr, "✘ %s (%s): %v\n", result.Workflow, target, result.Error)
+			continue
+		}
+		// Keep stdout clean for the payloads when they are streamed there.
+		fmt.Fprintf(reportWriter(dryRun), "✔ %s (%s): %d matrix combinations %s\n",
+			result.Workflow, target, result.Matrices, action)
+	}
+}
+
+func reportWriter(dryRun bool) io.Writer {
+	if dryRun {
+		return os.Stderr
+	}
+	return os.Stdout
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+)
+
+func handleValidateCommand(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+
+	configPath := fs.String(
+		"config",
+		"",
+		"Path to the configuration file (default: $CATALYST_CONFIG or ./catalyst.yaml)",
+	)
+	printSchema := fs.Bool("print-schema", false, "Print the JSON Schema for catalyst.yaml and exit")
+	outputFormat := fs.String("format", "text", "Output format for problems (text|json)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *printSchema {
+		fmt.Println(string(config.Schema()))
+		return nil
+	}
+
+	if *outputFormat != "text" && *outputFormat != "json" {
+		return fmt.Errorf("invalid format '%s'. Supported formats: text, json", *outputFormat)
+	}
+
+	path := config.ResolvePath(*configPath)
+
+	problems, err := config.ValidateFile(path)
+	if err != nil {
+		return err
+	}
+
+	if *outputFormat == "json" {
+		if problems == nil {
+			problems = []config.Problem{}
+		}
+		output, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling problems: %w", err)
+		}
+		fmt.Println(string(output))
+	} else {
+		for _, problem := range problems {
+			fmt.Println(problem.String())
+		}
+	}
+
+	errorCount := 0
+	for _, problem := range problems {
+		if problem.IsError() {
+			errorCount++
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("%s has %d error(s) and %d warning(s)",
+			path, errorCount, len(problems)-errorCount)
+	}
+
+	if *outputFormat == "text" {
+		if len(problems) > 0 {
+			fmt.Printf("%s is valid with %d warning(s)\n", path, len(problems))
+		} else {
+			fmt.Printf("%s is valid\n", path)
+		}
+		printUpcomingFreezes(path)
+	}
+	return nil
+}
+
+// printUpcomingFreezes lists the current or next period of every deployment
+// freeze window in the configuration.
+func printUpcomingFreezes(path string) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	freezes := cfg.Freezes(now)
+	if len(freezes) == 0 {
+		return
+	}
+
+	fmt.Println("\nUpcoming deployment freezes:")
+	for _, freeze := range freezes {
+		window := cfg.Freeze[freeze.Name]
+
+		scope := "all environments"
+		if len(window.Environments) > 0 {
+			scope = strings.Join(window.Environments, ", ")
+		}
+		if len(window.Apps) > 0 {
+			scope += " of " + strings.Join(window.Apps, ", ")
+		}
+
+		status := ""
+		if freeze.Active(now) {
+			status = " [active]"
+		}
+		if freeze.AllowOverride {
+			status += " [override allowed]"
+		}
+
+		fmt.Printf("  %s: %s – %s (%s)%s\n",
+			freeze.Name,
+			freeze.Start.Format(config.FreezeTimeFormat),
+			freeze.End.Format(config.FreezeTimeFormat),
+			scope,
+			status,
+		)
+		if freeze.Reason != "" {
+			fmt.Printf("    %s\n", freeze.Reason)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package audit appends a JSON lines record for every dispatch attempt to
+// the audit log, and reads the log back.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/git"
+)
+
+// Outcomes of a dispatch attempt. A retried attempt failed with an error
+// worth retrying and is followed by another attempt.
+const (
+	OutcomeDispatched = "dispatched"
+	OutcomeRetried    = "retried"
+	OutcomeFailed     = "failed"
+)
+
+// Record is a single dispatch attempt. Inputs are the workflow inputs sent
+// without the payload, InputValues the values of the configuration's inputs
+// entered for the deployment.
+type Record struct {
+	Timestamp   time.Time         `json:"timestamp"`
+	User        string            `json:"user"`
+	GitName     string            `json:"git_name,omitempty"`
+	GitEmail    string            `json:"git_email,omitempty"`
+	Repository  string            `json:"repository"`
+	Workflow    string            `json:"workflow"`
+	File        string            `json:"file"`
+	Ref         string            `json:"ref"`
+	Part        string            `json:"part,omitempty"`
+	Attempt     int               `json:"attempt"`
+	MatrixHash  string            `json:"matrix_hash"`
+	Inputs      map[string]string `json:"inputs"`
+	InputValues map[string]string `json:"input_values,omitempty"`
+	Outcome     string            `json:"outcome"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// Log appends records to the audit log at Path, stamped with the identity
+// of whoever runs Catalyst. Writing is best effort: the first error is kept
+// for Err instead of failing the dispatch being recorded.
+type Log struct {
+	Path     string
+	User     string
+	GitName  string
+	GitEmail string
+
+	mu  sync.Mutex
+	err error
+}
+
+// Path returns the location of the audit log: audit.path, otherwise
+// $CATALYST_AUDIT_LOG or audit.jsonl in Catalyst's user config directory.
+func Path(cfg *config.Config) (string, error) {
+	if cfg != nil && cfg.Audit.Path != "" {
+		return cfg.Audit.Path, nil
+	}
+	if path := os.Getenv("CATALYST_AUDIT_LOG"); path != "" {
+		return path, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "catalyst", "audit.jsonl"), nil
+}
+
+// Open prepares the audit log of the configuration. The git identity is
+// taken from the repository at changelog.path.
+func Open(cfg *config.Config) (*Log, error) {
+	path, err := Path(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &Log{Path: path, User: LocalUser()}
+	log.GitName, _ = git.Run(cfg.Changelog.Path, "config", "user.name")
+	log.GitEmail, _ = git.Run(cfg.Changelog.Path, "config", "user.email")
+	return log, nil
+}
+
+// LocalUser returns the name of the user running Catalyst.
+func LocalUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// Append stamps the record with the time and identity and writes it as a
+// single line. A nil log records nothing.
+func (l *Log) Append(record Record) {
+	if l == nil {
+		return
+	}
+
+	record.Timestamp = time.Now().UTC()
+	record.User = l.User
+	record.GitName = l.GitName
+	record.GitEmail = l.GitEmail
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.write(record); err != nil && l.err == nil {
+		l.err = err
+	}
+}
+
+func (l *Log) write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error marshaling audit record: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Err returns the first error that occurred while writing the log.
+func (l *Log) Err() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// MatrixHash identifies the matrices of a dispatch, so identical payloads
+// can be recognised without storing them.
+func MatrixHash(matrices []map[string]interface{}) string {
+	data, err := json.Marshal(matrices)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Read returns the records of the audit log in the order they were written.
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid audit record: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return records, nil
+}
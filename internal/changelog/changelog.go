@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/git"
+)
+
+const (
+	LastTag          = "last-tag"
+	DefaultMaxLength = 400
+)
+
+var conventionalPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+type group struct {
+	title string
+	types []string
+}
+
+var groups = []group{
+	{title: "Breaking Changes"},
+	{title: "Features", types: []string{"feat"}},
+	{title: "Fixes", types: []string{"fix"}},
+	{title: "Performance", types: []string{"perf"}},
+	{title: "Refactoring", types: []string{"refactor"}},
+	{title: "Documentation", types: []string{"docs"}},
+	{title: "Chores", types: []string{"chore", "build", "ci", "test", "style"}},
+	{title: "Other"},
+}
+
+type Options struct {
+	Dir       string
+	From      string
+	Target    string
+	TagPrefix string
+	Paths     []string
+	MaxLength int
+}
+
+// OptionsFor builds generation options from the configuration for the
+// selected apps. Paths are only filtered when every selected app has paths.
+func OptionsFor(cfg *config.Config, apps []string, from string, target string) Options {
+	opts := Options{
+		Dir:       cfg.Changelog.Path,
+		From:      from,
+		Target:    target,
+		TagPrefix: cfg.Changelog.TagPrefix,
+		MaxLength: MaxLength(cfg),
+	}
+
+	if opts.From == "" {
+		opts.From = cfg.Changelog.From
+	}
+
+	pathSet := make(map[string]bool)
+	for _, app := range apps {
+		paths := cfg.Changelog.Paths[app]
+		if len(paths) == 0 {
+			return opts
+		}
+		for _, path := range paths {
+			pathSet[path] = true
+		}
+	}
+
+	for path := range pathSet {
+		opts.Paths = append(opts.Paths, path)
+	}
+	sort.Strings(opts.Paths)
+
+	return opts
+}
+
+func MaxLength(cfg *config.Config) int {
+	if cfg.Changelog.MaxLength > 0 {
+		return cfg.Changelog.MaxLength
+	}
+	return DefaultMaxLength
+}
+
+func Generate(opts Options) (string, error) {
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	target, err := git.ResolveRef(dir, opts.Target)
+	if err != nil {
+		return "", err
+	}
+
+	from := opts.From
+	if from == "" || from == LastTag {
+		from, err = git.LatestTag(dir, target, opts.TagPrefix+"*")
+		if err != nil {
+			return "", err
+		}
+	} else if from, err = git.ResolveRef(dir, from); err != nil {
+		return "", err
+	}
+
+	commits, err := git.Log(dir, from, target, opts.Paths)
+	if err != nil {
+		return "", err
+	}
+
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits found between %s and %s", from, target)
+	}
+
+	return truncate(format(commits), opts.MaxLength), nil
+}
+
+func format(commits []git.Commit) string {
+	entries := make(map[string][]string)
+
+	for _, commit := range commits {
+		title, entry := classify(commit.Subject)
+		entries[title] = append(entries[title], entry)
+	}
+
+	var sections []string
+	for _, g := range groups {
+		if len(entries[g.title]) == 0 {
+			continue
+		}
+
+		var section strings.Builder
+		section.WriteString(g.title)
+		for _, entry := range entries[g.title] {
+			section.WriteString("\n- " + entry)
+		}
+		sections = append(sections, section.String())
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+func classify(subject string) (string, string) {
+	match := conventionalPattern.FindStringSubmatch(subject)
+	if match == nil {
+		return "Other", subject
+	}
+
+	commitType, scope, breaking, description := strings.ToLower(match[1]), match[2], match[3], match[4]
+
+	entry := description
+	if scope != "" {
+		entry = fmt.Sprintf("%s: %s", scope, description)
+	}
+
+	if breaking != "" {
+		return "Breaking Changes", entry
+	}
+
+	for _, g := range groups {
+		for _, t := range g.types {
+			if t == commitType {
+				return g.title, entry
+			}
+		}
+	}
+
+	return "Other", entry
+}
+
+// truncate keeps whole lines so the changelog fits the input length limit.
+func truncate(text string, maxLength int) string {
+	if maxLength <= 0 || len(text) <= maxLength {
+		return text
+	}
+
+	const marker = "\n…"
+
+	var kept []string
+	length := 0
+	for _, line := range strings.Split(text, "\n") {
+		if length+len(line)+1+len(marker) > maxLength {
+			break
+		}
+		kept = append(kept, line)
+		length += len(line) + 1
+	}
+
+	return strings.Join(kept, "\n") + marker
+}
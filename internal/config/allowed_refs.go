@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AllowsRef reports whether the environment may be deployed from ref. An
+// environment without allowed_refs accepts every ref; otherwise the ref
+// has to match one of the patterns.
+func (e EnvironmentConfig) AllowsRef(ref string) bool {
+	if len(e.AllowedRefs) == 0 {
+		return true
+	}
+
+	for _, pattern := range e.AllowedRefs {
+		if re, err := RefPattern(pattern); err == nil && re.MatchString(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// RefPattern compiles an allowed_refs pattern. Patterns enclosed in slashes
+// are regular expressions, e.g. /release\/\d+\.\d+/; all others are globs.
+// Both have to match the whole ref.
+func RefPattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		expr := pattern[1 : len(pattern)-1]
+		if _, err := regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %w", pattern, err)
+		}
+		return regexp.MustCompile("^(?:" + expr + ")$"), nil
+	}
+	return globPattern(pattern)
+}
+
+func (c *Config) validateAllowedRefs(v *validation) {
+	for _, app := range sortedKeys(c.Matrix) {
+		for _, platform := range sortedKeys(c.Matrix[app]) {
+			for _, env := range sortedKeys(c.Matrix[app][platform]) {
+				for _, pattern := range c.Matrix[app][platform][env].AllowedRefs {
+					if _, err := RefPattern(pattern); err != nil {
+						v.add([]string{"matrix", app, platform, env, "allowed_refs"},
+							"app %s platform %s environment %s: %v", app, platform, env, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// globPattern compiles a glob like the branch patterns of GitHub: * matches
+// within a path segment, ** across segments, ? a single character and
+// [...] a character class.
+func globPattern(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class in %s", pattern)
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
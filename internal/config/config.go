@@ -20,117 +20,246 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
-
-	"github.com/PraveenGongada/catalyst/internal/constants"
 )
 
 type Config struct {
-	GitHub GitHubConfig                         `yaml:"github"`
-	Inputs map[string]InputConfig               `yaml:"inputs"`
-	Matrix map[string]map[string]PlatformConfig `yaml:"matrix"`
+	Include       []string                      `yaml:"include"`
+	GitHub        GitHubConfig                  `yaml:"github"`
+	Inputs        map[string]InputConfig        `yaml:"inputs"`
+	Presets       map[string]PresetConfig       `yaml:"presets"`
+	Changelog     ChangelogConfig               `yaml:"changelog"`
+	Freeze        map[string]FreezeConfig       `yaml:"freeze"`
+	Audit         AuditConfig                   `yaml:"audit"`
+	Notifications map[string]NotificationConfig `yaml:"notifications"`
+	Matrix        map[string]AppConfig          `yaml:"matrix"`
+
+	file            string
+	root            *yaml.Node
+	origins         map[*yaml.Node]string
+	includeProblems []Problem
 }
 
 type GitHubConfig struct {
 	Repository string                    `yaml:"repository"`
+	Backend    string                    `yaml:"backend"`
+	APIURL     string                    `yaml:"api_url"`
+	Dispatch   DispatchConfig            `yaml:"dispatch"`
 	Workflows  map[string]WorkflowConfig `yaml:"workflows"`
 }
 
+// DispatchConfig controls how many dispatches run at once and how often a
+// dispatch failing with a retriable error is attempted.
+type DispatchConfig struct {
+	Concurrency int `yaml:"concurrency"`
+	MaxAttempts int `yaml:"max_attempts"`
+}
+
 type WorkflowConfig struct {
-	Name string `yaml:"name"`
-	File string `yaml:"file"`
+	Name       string               `yaml:"name"`
+	File       string               `yaml:"file"`
+	Repository string               `yaml:"repository"`
+	Ref        string               `yaml:"ref"`
+	Inputs     WorkflowInputsConfig `yaml:"inputs"`
+	Split      SplitConfig          `yaml:"split"`
+}
+
+// SplitConfig limits the size of a single dispatch. Matrices beyond the
+// limits are dispatched to the workflow in further runs.
+type SplitConfig struct {
+	MaxPayloadBytes int `yaml:"max_payload_bytes"`
+	MaxMatrices     int `yaml:"max_matrices"`
 }
 
 type InputConfig struct {
-	Description string `yaml:"description"`
-	Required    bool   `yaml:"required"`
-	Default     string `yaml:"default"`
+	Description string      `yaml:"description"`
+	Required    bool        `yaml:"required"`
+	Default     string      `yaml:"default"`
+	Type        string      `yaml:"type"`
+	Options     []string    `yaml:"options"`
+	Pattern     string      `yaml:"pattern"`
+	Min         *float64    `yaml:"min"`
+	Max         *float64    `yaml:"max"`
+	Source      InputSource `yaml:"source"`
+}
+
+type ChangelogConfig struct {
+	From      string              `yaml:"from"`
+	TagPrefix string              `yaml:"tag_prefix"`
+	Path      string              `yaml:"path"`
+	Prefill   bool                `yaml:"prefill"`
+	MaxLength int                 `yaml:"max_length"`
+	Paths     map[string][]string `yaml:"paths"`
+}
+
+// AuditConfig locates the audit log, which records every dispatch attempt.
+type AuditConfig struct {
+	Path string `yaml:"path"`
+}
+
+type PresetConfig struct {
+	Description  string            `yaml:"description"`
+	Apps         []string          `yaml:"apps"`
+	Platforms    []string          `yaml:"platforms"`
+	Environments []string          `yaml:"environments"`
+	Inputs       map[string]string `yaml:"inputs"`
 }
 
+type AppConfig map[string]PlatformConfig
+
 type PlatformConfig map[string]EnvironmentConfig
 
 type EnvironmentConfig struct {
-	Workflow string                 `yaml:"workflow"`
-	Matrix   map[string]interface{} `yaml:"matrix"`
+	Workflow    string                 `yaml:"workflow"`
+	Protected   bool                   `yaml:"protected"`
+	AllowedRefs []string               `yaml:"allowed_refs"`
+	Matrix      map[string]interface{} `yaml:"matrix"`
 }
 
-func Load(path string) (*Config, error) {
-	if path == "" {
-		if envPath := os.Getenv("CATALYST_CONFIG"); envPath != "" {
-			path = envPath
-		} else {
-			path = "catalyst.yaml"
-			if _, err := os.Stat(path); os.IsNotExist(err) {
-				configDir, err := os.UserConfigDir()
-				if err == nil {
-					path = filepath.Join(configDir, "catalyst", "catalyst.yaml")
-				}
-			}
+// WorkflowRepository returns the repository the workflow is dispatched to,
+// which defaults to github.repository.
+func (g GitHubConfig) WorkflowRepository(key string) string {
+	if wf, ok := g.Workflows[key]; ok && wf.Repository != "" {
+		return wf.Repository
+	}
+	return g.Repository
+}
+
+// WorkflowRef returns the ref the workflow is dispatched on: its own ref if
+// configured, otherwise the branch selected for the deployment.
+func (g GitHubConfig) WorkflowRef(key string, branch string) string {
+	if wf, ok := g.Workflows[key]; ok && wf.Ref != "" {
+		return wf.Ref
+	}
+	return branch
+}
+
+func ResolvePath(path string) string {
+	if path != "" {
+		return path
+	}
+
+	if envPath := os.Getenv("CATALYST_CONFIG"); envPath != "" {
+		return envPath
+	}
+
+	path = "catalyst.yaml"
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		configDir, err := os.UserConfigDir()
+		if err == nil {
+			path = filepath.Join(configDir, "catalyst", "catalyst.yaml")
 		}
 	}
+	return path
+}
+
+func Load(path string) (*Config, error) {
+	path = ResolvePath(path)
 
-	data, err := os.ReadFile(path)
+	doc, err := loadDocument(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		return nil, err
 	}
 
 	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	if err := doc.root.Decode(&config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	config.setDocument(doc)
 
 	return &config, nil
 }
 
-func (c *Config) Validate() error {
-	if c.GitHub.Repository == "" {
-		return fmt.Errorf("GitHub repository is required")
+func (c *Config) setDocument(doc *document) {
+	c.file = doc.file
+	c.root = doc.root
+	c.origins = doc.origins
+	c.includeProblems = doc.problems
+}
+
+func (c *Config) GetPresets() []string {
+	presets := make([]string, 0, len(c.Presets))
+	for preset := range c.Presets {
+		presets = append(presets, preset)
 	}
-	if len(c.GitHub.Workflows) == 0 {
-		return fmt.Errorf("at least one workflow is required")
+	sort.Strings(presets)
+	return presets
+}
+
+// ResolvePreset expands wildcards and maps the preset's names onto the
+// spelling used in the matrix, so they can be compared with list titles.
+// Lists left empty in the preset stay empty and are not narrowed further.
+func (c *Config) ResolvePreset(name string) (PresetConfig, error) {
+	preset, ok := c.Presets[name]
+	if !ok {
+		return PresetConfig{}, fmt.Errorf("preset '%s' not found. Available presets: %v",
+			name, c.GetPresets())
 	}
 
-	if len(c.Matrix) == 0 {
-		return fmt.Errorf("at least one app is required in matrix")
+	apps, err := resolveNames(name, "app", preset.Apps, c.GetApps())
+	if err != nil {
+		return PresetConfig{}, err
 	}
 
-	for app, platforms := range c.Matrix {
-		if len(platforms) == 0 {
-			return fmt.Errorf("app %s has no platforms", app)
-		}
+	appScope := apps
+	if len(appScope) == 0 {
+		appScope = c.GetApps()
+	}
 
-		for platform, environments := range platforms {
-			if len(environments) == 0 {
-				return fmt.Errorf("app %s platform %s has no environments", app, platform)
-			}
+	platforms, err := resolveNames(name, "platform", preset.Platforms, c.GetPlatforms(appScope))
+	if err != nil {
+		return PresetConfig{}, err
+	}
 
-			for env, config := range environments {
-				if config.Workflow == "" {
-					return fmt.Errorf(
-						"app %s platform %s environment %s has no workflow",
-						app,
-						platform,
-						env,
-					)
-				}
+	platformScope := platforms
+	if len(platformScope) == 0 {
+		platformScope = c.GetPlatforms(appScope)
+	}
 
-				if _, ok := c.GitHub.Workflows[config.Workflow]; !ok {
-					return fmt.Errorf(
-						"app %s platform %s environment %s references unknown workflow %s",
-						app,
-						platform,
-						env,
-						config.Workflow,
-					)
-				}
-			}
+	environments, err := resolveNames(
+		name,
+		"environment",
+		preset.Environments,
+		c.GetEnvironments(appScope, platformScope),
+	)
+	if err != nil {
+		return PresetConfig{}, err
+	}
+
+	return PresetConfig{
+		Description:  preset.Description,
+		Apps:         apps,
+		Platforms:    platforms,
+		Environments: environments,
+		Inputs:       preset.Inputs,
+	}, nil
+}
+
+func resolveNames(preset, kind string, names []string, available []string) ([]string, error) {
+	availableMap := make(map[string]string)
+	for _, item := range available {
+		availableMap[strings.ToLower(item)] = item
+	}
+
+	resolved := []string{}
+	for _, name := range names {
+		if name == "*" {
+			sorted := append([]string{}, available...)
+			sort.Strings(sorted)
+			return sorted, nil
+		}
+
+		actual, ok := availableMap[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("preset %s references unknown %s %s", preset, kind, name)
 		}
+		resolved = append(resolved, actual)
 	}
 
-	return nil
+	return resolved, nil
 }
 
 func (c *Config) GetApps() []string {
@@ -196,26 +325,3 @@ func (c *Config) GetEnvironments(apps []string, platforms []string) []string {
 
 	return environments
 }
-
-var variablePattern = regexp.MustCompile(constants.RegexInputPlaceholder)
-
-func (c *Config) SubstituteVariables(value string, inputValues map[string]string) string {
-	return variablePattern.ReplaceAllStringFunc(value, func(match string) string {
-		matches := variablePattern.FindStringSubmatch(match)
-		if len(matches) < 2 {
-			return match
-		}
-
-		varName := matches[1]
-
-		if val, ok := inputValues[varName]; ok {
-			return val
-		}
-
-		if input, ok := c.Inputs[varName]; ok {
-			return input.Default
-		}
-
-		return match
-	})
-}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultsKey is the key of the app and platform level settings inherited by
+// every environment below them.
+const DefaultsKey = "defaults"
+
+// UnmarshalYAML applies the app level defaults to every environment of the
+// app. Platform level defaults have already been applied by then, so they
+// take precedence over the app's.
+func (a *AppConfig) UnmarshalYAML(node *yaml.Node) error {
+	var raw map[string]yaml.Node
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	defaults, err := decodeDefaults(raw)
+	if err != nil {
+		return err
+	}
+
+	app := make(AppConfig, len(raw))
+	for name, value := range raw {
+		var platform PlatformConfig
+		if err := value.Decode(&platform); err != nil {
+			return err
+		}
+
+		for env, envConfig := range platform {
+			platform[env] = envConfig.inherit(defaults)
+		}
+		app[name] = platform
+	}
+
+	*a = app
+	return nil
+}
+
+// UnmarshalYAML applies the platform level defaults to every environment of
+// the platform.
+func (p *PlatformConfig) UnmarshalYAML(node *yaml.Node) error {
+	var raw map[string]yaml.Node
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	defaults, err := decodeDefaults(raw)
+	if err != nil {
+		return err
+	}
+
+	platform := make(PlatformConfig, len(raw))
+	for name, value := range raw {
+		var envConfig EnvironmentConfig
+		if err := value.Decode(&envConfig); err != nil {
+			return err
+		}
+		platform[name] = envConfig.inherit(defaults)
+	}
+
+	*p = platform
+	return nil
+}
+
+// decodeDefaults removes the defaults entry from raw and decodes it.
+func decodeDefaults(raw map[string]yaml.Node) (EnvironmentConfig, error) {
+	var defaults EnvironmentConfig
+
+	node, ok := raw[DefaultsKey]
+	if !ok {
+		return defaults, nil
+	}
+	delete(raw, DefaultsKey)
+
+	if err := node.Decode(&defaults); err != nil {
+		return defaults, err
+	}
+	return defaults, nil
+}
+
+// inherit fills in the workflow, allowed refs and matrix values not set by
+// the environment itself. Matrix values are merged key by key; the environment's values win.
+// Protection cannot be lifted by an environment below protected defaults.
+func (e EnvironmentConfig) inherit(defaults EnvironmentConfig) EnvironmentConfig {
+	if e.Workflow == "" {
+		e.Workflow = defaults.Workflow
+	}
+	if defaults.Protected {
+		e.Protected = true
+	}
+	if len(e.AllowedRefs) == 0 {
+		e.AllowedRefs = defaults.AllowedRefs
+	}
+
+	if len(defaults.Matrix) == 0 {
+		return e
+	}
+
+	merged := make(map[string]interface{}, len(defaults.Matrix)+len(e.Matrix))
+	for key, value := range defaults.Matrix {
+		merged[key] = value
+	}
+	for key, value := range e.Matrix {
+		merged[key] = value
+	}
+	e.Matrix = merged
+
+	return e
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/cron"
+)
+
+// FreezeTimeFormat is how the start and end of a freeze are displayed.
+const FreezeTimeFormat = "2006-01-02 15:04 MST"
+
+// freezeDateLayouts are accepted for from and to. A date without a time
+// starts at midnight; as to, the whole day is included.
+var freezeDateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04"}
+
+const freezeDateOnly = "2006-01-02"
+
+// FreezeConfig is a window in which deployments are refused: either a date
+// range (from/to) or a recurring cron schedule lasting for duration. Apps
+// and environments narrow the window; left empty, it applies to all.
+type FreezeConfig struct {
+	Reason        string   `yaml:"reason"`
+	From          string   `yaml:"from"`
+	To            string   `yaml:"to"`
+	Schedule      string   `yaml:"schedule"`
+	Duration      string   `yaml:"duration"`
+	Timezone      string   `yaml:"timezone"`
+	Apps          []string `yaml:"apps"`
+	Environments  []string `yaml:"environments"`
+	AllowOverride bool     `yaml:"allow_override"`
+}
+
+// Freeze is a single period of a freeze window.
+type Freeze struct {
+	Name          string
+	Reason        string
+	Start         time.Time
+	End           time.Time
+	AllowOverride bool
+}
+
+func (f Freeze) String() string {
+	description := fmt.Sprintf("%s, in effect until %s", f.Name, f.End.Format(FreezeTimeFormat))
+	if f.Reason != "" {
+		description += " (" + f.Reason + ")"
+	}
+	return description
+}
+
+// Active reports whether the freeze is in effect at t.
+func (f Freeze) Active(t time.Time) bool {
+	return !t.Before(f.Start) && t.Before(f.End)
+}
+
+// Covers reports whether the window applies to the environment of the app.
+func (f FreezeConfig) Covers(app, environment string) bool {
+	return matchesAny(f.Apps, app) && matchesAny(f.Environments, environment)
+}
+
+func matchesAny(names []string, name string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	for _, candidate := range names {
+		if candidate == "*" || strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f FreezeConfig) location() (*time.Location, error) {
+	if f.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(f.Timezone)
+}
+
+// period returns the period of the window that is in effect at now or
+// starts next. It reports false if the window has no such period.
+func (f FreezeConfig) period(now time.Time) (time.Time, time.Time, bool, error) {
+	loc, err := f.location()
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("unknown timezone %s", f.Timezone)
+	}
+	now = now.In(loc)
+
+	if f.Schedule != "" {
+		schedule, err := cron.Parse(f.Schedule)
+		if err != nil {
+			return time.Time{}, time.Time{}, false, err
+		}
+
+		duration, err := time.ParseDuration(f.Duration)
+		if err != nil || duration <= 0 {
+			return time.Time{}, time.Time{}, false,
+				fmt.Errorf("duration %q must be a positive duration such as 2h or 62h30m", f.Duration)
+		}
+
+		// The first start after now-duration is either still running or
+		// the next one to come.
+		start := schedule.Next(now.Add(-duration))
+		if start.IsZero() {
+			return time.Time{}, time.Time{}, false, nil
+		}
+		return start, start.Add(duration), true, nil
+	}
+
+	start, err := parseFreezeTime(f.From, loc, false)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid from: %w", err)
+	}
+	end, err := parseFreezeTime(f.To, loc, true)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid to: %w", err)
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("to must be after from")
+	}
+
+	return start, end, end.After(now), nil
+}
+
+func parseFreezeTime(value string, loc *time.Location, end bool) (time.Time, error) {
+	if t, err := time.ParseInLocation(freezeDateOnly, value, loc); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	for _, layout := range freezeDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not a date (2006-01-02), date and time (2006-01-02 15:04) or RFC 3339 timestamp", value)
+}
+
+// Freezes returns the current or next period of every freeze window, in
+// order of their start. Windows that are over or invalid are left out.
+func (c *Config) Freezes(now time.Time) []Freeze {
+	var freezes []Freeze
+
+	for _, name := range sortedKeys(c.Freeze) {
+		window := c.Freeze[name]
+
+		start, end, ok, err := window.period(now)
+		if err != nil || !ok {
+			continue
+		}
+
+		freezes = append(freezes, Freeze{
+			Name:          name,
+			Reason:        window.Reason,
+			Start:         start,
+			End:           end,
+			AllowOverride: window.AllowOverride,
+		})
+	}
+
+	sort.SliceStable(freezes, func(i, j int) bool {
+		return freezes[i].Start.Before(freezes[j].Start)
+	})
+	return freezes
+}
+
+// Overridable reports whether all of the freezes allow an override.
+func Overridable(freezes []Freeze) bool {
+	for _, freeze := range freezes {
+		if !freeze.AllowOverride {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckFreezes returns an error if the freezes in effect block the
+// deployment: a freeze that cannot be overridden always does, the others
+// only as long as no override reason is given.
+func CheckFreezes(freezes []Freeze, reason string) error {
+	for _, freeze := range freezes {
+		if !freeze.AllowOverride {
+			return fmt.Errorf("deployment freeze %s; it cannot be overridden", freeze)
+		}
+	}
+
+	if len(freezes) > 0 && strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("deployment freeze %s; an override reason is required", freezes[0])
+	}
+	return nil
+}
+
+// FreezeOverrideNote appends the override of the freezes and its reason to
+// the changelog, so it is part of what the workflows receive.
+func FreezeOverrideNote(changeLog string, freezes []Freeze, reason string) string {
+	if len(freezes) == 0 || strings.TrimSpace(reason) == "" {
+		return changeLog
+	}
+
+	names := make([]string, len(freezes))
+	for i, freeze := range freezes {
+		names[i] = fmt.Sprintf("%s (until %s)", freeze.Name, freeze.End.Format(FreezeTimeFormat))
+	}
+
+	return fmt.Sprintf("%s\n\nDeployed during freeze %s. Override reason: %s",
+		strings.TrimRight(changeLog, "\n"), strings.Join(names, ", "), strings.TrimSpace(reason))
+}
+
+func (c *Config) validateFreeze(v *validation) {
+	apps := make(map[string]bool)
+	for _, app := range c.GetApps() {
+		apps[strings.ToLower(app)] = true
+	}
+
+	environments := make(map[string]bool)
+	for _, env := range c.GetEnvironments(c.GetApps(), c.GetPlatforms(c.GetApps())) {
+		environments[strings.ToLower(env)] = true
+	}
+
+	for _, name := range sortedKeys(c.Freeze) {
+		window := c.Freeze[name]
+		path := []string{"freeze", name}
+
+		hasRange := window.From != "" || window.To != ""
+		switch {
+		case window.Schedule != "" && hasRange:
+			v.add(path, "freeze window %s must use either schedule or from/to, not both", name)
+			continue
+		case window.Schedule == "" && (window.From == "" || window.To == ""):
+			v.add(path, "freeze window %s needs a schedule and duration, or from and to", name)
+			continue
+		case window.Schedule == "" && window.Duration != "":
+			v.add(append(path, "duration"), "freeze window %s: duration requires a schedule", name)
+		}
+
+		loc, err := window.location()
+		if err != nil {
+			v.add(append(path, "timezone"), "freeze window %s has unknown timezone %s", name, window.Timezone)
+			continue
+		}
+
+		if window.Schedule != "" {
+			if _, err := cron.Parse(window.Schedule); err != nil {
+				v.add(append(path, "schedule"), "freeze window %s: %v", name, err)
+			}
+			if duration, err := time.ParseDuration(window.Duration); err != nil || duration <= 0 {
+				v.add(append(path, "duration"),
+					"freeze window %s: duration %q must be a positive duration such as 2h or 62h30m",
+					name, window.Duration)
+			}
+		} else {
+			from, fromErr := parseFreezeTime(window.From, loc, false)
+			if fromErr != nil {
+				v.add(append(path, "from"), "freeze window %s: invalid from: %v", name, fromErr)
+			}
+			to, toErr := parseFreezeTime(window.To, loc, true)
+			if toErr != nil {
+				v.add(append(path, "to"), "freeze window %s: invalid to: %v", name, toErr)
+			}
+			if fromErr == nil && toErr == nil && !to.After(from) {
+				v.add(append(path, "to"), "freeze window %s ends before it starts", name)
+			}
+		}
+
+		for _, app := range window.Apps {
+			if app != "*" && !apps[strings.ToLower(app)] {
+				v.add(append(path, "apps"), "freeze window %s references unknown app %s", name, app)
+			}
+		}
+		for _, env := range window.Environments {
+			if env != "*" && !environments[strings.ToLower(env)] {
+				v.add(append(path, "environments"),
+					"freeze window %s references unknown environment %s", name, env)
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func utc(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		t.Fatalf("invalid test time %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestFreezeWindows(t *testing.T) {
+	// 2025-01-03 is a Friday.
+	weekend := FreezeConfig{Schedule: "0 18 * * fri", Duration: "62h", Timezone: "UTC"}
+	holidays := FreezeConfig{From: "2025-12-24", To: "2025-12-26", Timezone: "UTC"}
+	release := FreezeConfig{From: "2025-03-01 09:00", To: "2025-03-01T17:30:00Z", Timezone: "UTC"}
+
+	tests := []struct {
+		name       string
+		window     FreezeConfig
+		now        string
+		wantActive bool
+		wantStart  string
+		wantEnd    string
+	}{
+		{name: "before a recurring freeze", window: weekend, now: "2025-01-03 17:59",
+			wantStart: "2025-01-03 18:00", wantEnd: "2025-01-06 08:00"},
+		{name: "recurring freeze starts", window: weekend, now: "2025-01-03 18:00", wantActive: true,
+			wantStart: "2025-01-03 18:00", wantEnd: "2025-01-06 08:00"},
+		{name: "during a recurring freeze", window: weekend, now: "2025-01-05 12:00", wantActive: true,
+			wantStart: "2025-01-03 18:00", wantEnd: "2025-01-06 08:00"},
+		{name: "last minute of a recurring freeze", window: weekend, now: "2025-01-06 07:59", wantActive: true,
+			wantStart: "2025-01-03 18:00", wantEnd: "2025-01-06 08:00"},
+		{name: "recurring freeze ends", window: weekend, now: "2025-01-06 08:00",
+			wantStart: "2025-01-10 18:00", wantEnd: "2025-01-13 08:00"},
+		{name: "before a date range", window: holidays, now: "2025-12-23 23:59",
+			wantStart: "2025-12-24 00:00", wantEnd: "2025-12-27 00:00"},
+		{name: "date range starts", window: holidays, now: "2025-12-24 00:00", wantActive: true,
+			wantStart: "2025-12-24 00:00", wantEnd: "2025-12-27 00:00"},
+		{name: "last day of a date range", window: holidays, now: "2025-12-26 23:59", wantActive: true,
+			wantStart: "2025-12-24 00:00", wantEnd: "2025-12-27 00:00"},
+		{name: "date and time range", window: release, now: "2025-03-01 17:29", wantActive: true,
+			wantStart: "2025-03-01 09:00", wantEnd: "2025-03-01 17:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Freeze: map[string]FreezeConfig{"freeze": tt.window}}
+			now := utc(t, tt.now)
+
+			freezes := cfg.Freezes(now)
+			if len(freezes) != 1 {
+				t.Fatalf("got %d freezes, want 1", len(freezes))
+			}
+
+			freeze := freezes[0]
+			if active := freeze.Active(now); active != tt.wantActive {
+				t.Errorf("Active = %t, want %t", active, tt.wantActive)
+			}
+			if want := utc(t, tt.wantStart); !freeze.Start.Equal(want) {
+				t.Errorf("Start = %s, want %s", freeze.Start, want)
+			}
+			if want := utc(t, tt.wantEnd); !freeze.End.Equal(want) {
+				t.Errorf("End = %s, want %s", freeze.End, want)
+			}
+		})
+	}
+}
+
+func TestFreezeWindowsOver(t *testing.T) {
+	cfg := &Config{Freeze: map[string]FreezeConfig{
+		"holidays": {From: "2025-12-24", To: "2025-12-26", Timezone: "UTC"},
+		"invalid":  {Schedule: "0 18 * * fri", Duration: "-1h", Timezone: "UTC"},
+		"never":    {Schedule: "0 0 30 feb *", Duration: "1h", Timezone: "UTC"},
+	}}
+
+	if freezes := cfg.Freezes(utc(t, "2025-12-27 00:00")); len(freezes) != 0 {
+		t.Errorf("got freezes %v, want none", freezes)
+	}
+}
+
+func TestFreezeTimezone(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	cfg := &Config{Freeze: map[string]FreezeConfig{
+		"evening": {Schedule: "0 18 * * *", Duration: "1h", Timezone: "Europe/Berlin"},
+	}}
+
+	// 18:00 in Berlin is 17:00 UTC in winter.
+	tests := []struct {
+		now        string
+		wantActive bool
+	}{
+		{now: "2025-01-01 16:59"},
+		{now: "2025-01-01 17:00", wantActive: true},
+		{now: "2025-01-01 17:59", wantActive: true},
+		{now: "2025-01-01 18:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.now, func(t *testing.T) {
+			now := utc(t, tt.now)
+			freezes := cfg.Freezes(now)
+			if len(freezes) != 1 {
+				t.Fatalf("got %d freezes, want 1", len(freezes))
+			}
+			if active := freezes[0].Active(now); active != tt.wantActive {
+				t.Errorf("Active = %t, want %t (period %s - %s)",
+					active, tt.wantActive, freezes[0].Start, freezes[0].End)
+			}
+		})
+	}
+}
+
+func TestFreezesOrder(t *testing.T) {
+	cfg := &Config{Freeze: map[string]FreezeConfig{
+		"a-later":   {From: "2025-02-01", To: "2025-02-02", Timezone: "UTC"},
+		"b-current": {From: "2025-01-01", To: "2025-01-31", Timezone: "UTC"},
+	}}
+
+	freezes := cfg.Freezes(utc(t, "2025-01-15 12:00"))
+	if len(freezes) != 2 || freezes[0].Name != "b-current" || freezes[1].Name != "a-later" {
+		t.Errorf("freezes = %v, want b-current then a-later", freezes)
+	}
+}
+
+func TestFreezeCovers(t *testing.T) {
+	tests := []struct {
+		name        string
+		window      FreezeConfig
+		app         string
+		environment string
+		want        bool
+	}{
+		{name: "everything", window: FreezeConfig{}, app: "App", environment: "Prod", want: true},
+		{name: "environment", window: FreezeConfig{Environments: []string{"prod"}}, app: "App", environment: "Prod", want: true},
+		{name: "other environment", window: FreezeConfig{Environments: []string{"Prod"}}, app: "App", environment: "Dev"},
+		{name: "app and environment", window: FreezeConfig{Apps: []string{"App"}, Environments: []string{"*"}},
+			app: "app", environment: "Dev", want: true},
+		{name: "other app", window: FreezeConfig{Apps: []string{"Other"}}, app: "App", environment: "Prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Covers(tt.app, tt.environment); got != tt.want {
+				t.Errorf("Covers(%s, %s) = %t, want %t", tt.app, tt.environment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckFreezes(t *testing.T) {
+	end := time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)
+	overridable := Freeze{Name: "weekend", End: end, AllowOverride: true}
+	strict := Freeze{Name: "holidays", End: end}
+
+	tests := []struct {
+		name     string
+		freezes  []Freeze
+		reason   string
+		wantText string
+	}{
+		{name: "no freeze"},
+		{name: "no reason", freezes: []Freeze{overridable}, wantText: "an override reason is required"},
+		{name: "blank reason", freezes: []Freeze{overridable}, reason: "  ", wantText: "an override reason is required"},
+		{name: "overridden", freezes: []Freeze{overridable}, reason: "hotfix"},
+		{name: "not overridable", freezes: []Freeze{overridable, strict}, reason: "hotfix",
+			wantText: "deployment freeze holidays, in effect until 2025-01-06 08:00 UTC; it cannot be overridden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckFreezes(tt.freezes, tt.reason)
+			if tt.wantText == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+
+	if Overridable([]Freeze{overridable, strict}) {
+		t.Error("Overridable reported true for a freeze that does not allow overrides")
+	}
+}
@@ -0,0 +1,329 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// includeDepths lists the sections an included file may contribute to and
+// how many mapping levels are merged before two definitions conflict, e.g.
+// matrix entries conflict per app, platform and environment.
+var includeDepths = map[string]int{
+	"inputs":  1,
+	"presets": 1,
+	"matrix":  3,
+}
+
+// document is a configuration file with its included fragments merged in.
+// origins records the file of every node that came from a fragment.
+type document struct {
+	file     string
+	root     *yaml.Node
+	origins  map[*yaml.Node]string
+	problems []Problem
+}
+
+func parseFile(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return &root, nil
+}
+
+func loadDocument(path string) (*document, error) {
+	root, err := parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &document{
+		file:    path,
+		root:    root,
+		origins: make(map[*yaml.Node]string),
+	}
+
+	if err := doc.include(); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (d *document) fileOf(node *yaml.Node) string {
+	if file, ok := d.origins[node]; ok {
+		return file
+	}
+	return d.file
+}
+
+func (d *document) report(severity, file string, node *yaml.Node, path []string, format string, args ...interface{}) {
+	d.problems = append(d.problems, Problem{
+		Severity: severity,
+		File:     file,
+		Path:     strings.Join(path, "."),
+		Line:     node.Line,
+		Column:   node.Column,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *document) include() error {
+	main := documentMapping(d.root)
+	if main == nil {
+		return nil
+	}
+
+	pair := findPair(main, "include")
+	if pair == nil || pair.value.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	dir := filepath.Dir(d.file)
+	seen := map[string]bool{filepath.Clean(d.file): true}
+
+	for _, patternNode := range pair.value.Content {
+		if patternNode.Kind != yaml.ScalarNode || patternNode.Value == "" {
+			continue
+		}
+
+		pattern := patternNode.Value
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("%s:%d:%d: invalid include pattern %s: %w",
+				d.file, patternNode.Line, patternNode.Column, patternNode.Value, err)
+		}
+
+		if len(matches) == 0 {
+			d.report(SeverityWarning, d.file, patternNode, []string{"include"},
+				"include pattern %s matches no files", patternNode.Value)
+			continue
+		}
+
+		// filepath.Glob returns matches in lexical order, which keeps the
+		// merge order, and therefore the conflict reports, deterministic.
+		for _, match := range matches {
+			if seen[filepath.Clean(match)] {
+				continue
+			}
+			seen[filepath.Clean(match)] = true
+
+			fragment, err := parseFile(match)
+			if err != nil {
+				return err
+			}
+			d.merge(main, match, fragment)
+		}
+	}
+
+	return nil
+}
+
+func (d *document) merge(main *yaml.Node, file string, fragment *yaml.Node) {
+	node := documentMapping(fragment)
+	if node == nil {
+		return
+	}
+	markOrigin(d.origins, fragment, file)
+
+	for _, pair := range mappingPairs(node) {
+		section := pair.key.Value
+
+		if section == "github" {
+			d.mergeGitHub(main, file, pair)
+			continue
+		}
+
+		// Extension keys only hold anchors for the file's own aliases.
+		if strings.HasPrefix(section, "x-") {
+			continue
+		}
+
+		depth, ok := includeDepths[section]
+		if !ok {
+			d.report(SeverityError, file, pair.key, []string{section},
+				"%s cannot be set in an included file (only inputs, presets, matrix and github.workflows)",
+				section)
+			continue
+		}
+
+		d.mergeInto(main, file, pair, nil, depth)
+	}
+}
+
+func (d *document) mergeGitHub(main *yaml.Node, file string, github nodePair) {
+	value := resolveAlias(github.value)
+	if value.Kind != yaml.MappingNode {
+		return
+	}
+
+	for _, pair := range mappingPairs(value) {
+		if pair.key.Value != "workflows" {
+			d.report(SeverityError, file, pair.key, []string{"github", pair.key.Value},
+				"github.%s cannot be set in an included file (only github.workflows)",
+				pair.key.Value)
+			continue
+		}
+
+		target := findPair(main, "github")
+		if target == nil {
+			main.Content = append(main.Content, github.key, &yaml.Node{Kind: yaml.MappingNode})
+			target = findPair(main, "github")
+		}
+
+		parent := d.ownValue(main, *target)
+		if parent.Kind != yaml.MappingNode {
+			continue
+		}
+		d.mergeInto(parent, file, pair, []string{"github"}, 1)
+	}
+}
+
+// mergeInto adds pair to the parent mapping. Existing mappings are merged
+// depth levels deep; below that, a key defined twice is a conflict and the
+// first definition wins.
+func (d *document) mergeInto(parent *yaml.Node, file string, pair nodePair, path []string, depth int) {
+	path = append(append([]string{}, path...), pair.key.Value)
+
+	existing := findPair(parent, pair.key.Value)
+	if existing == nil {
+		parent.Content = append(parent.Content, pair.key, pair.value)
+		return
+	}
+
+	target := d.ownValue(parent, *existing)
+	if target.Kind == yaml.ScalarNode && target.Tag == "!!null" {
+		*target = *pair.value
+		d.origins[target] = file
+		return
+	}
+
+	value := resolveAlias(pair.value)
+	if depth == 0 || target.Kind != yaml.MappingNode || value.Kind != yaml.MappingNode {
+		d.report(SeverityError, file, pair.key, path,
+			"%s is already defined in %s:%d",
+			strings.Join(path, "."), d.fileOf(existing.key), existing.key.Line)
+		return
+	}
+
+	for _, child := range mappingPairs(value) {
+		d.mergeInto(target, file, child, path, depth-1)
+	}
+}
+
+// ownValue returns the value of existing, a pair of parent, to merge into.
+// Values shared with other parts of the document (aliases, anchored nodes
+// and values pulled in by a merge key) are first replaced by a copy in
+// parent, so that merging does not change them everywhere they are used.
+func (d *document) ownValue(parent *yaml.Node, existing nodePair) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i] != existing.key {
+			continue
+		}
+
+		value := parent.Content[i+1]
+		if value.Kind == yaml.AliasNode || value.Anchor != "" {
+			value = d.copyNode(resolveAlias(value))
+			parent.Content[i+1] = value
+		}
+		return value
+	}
+
+	// The pair comes from a merge key; an explicit key overrides it.
+	value := d.copyNode(resolveAlias(existing.value))
+	parent.Content = append(parent.Content, existing.key, value)
+	return value
+}
+
+// copyNode deep-copies node without its anchors, keeping the origins of the
+// copied nodes. Aliases are kept and copied when merged into.
+func (d *document) copyNode(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		return node
+	}
+
+	copied := *node
+	copied.Anchor = ""
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = d.copyNode(child)
+	}
+
+	if file, ok := d.origins[node]; ok {
+		d.origins[&copied] = file
+	}
+	return &copied
+}
+
+func documentMapping(root *yaml.Node) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	return node
+}
+
+func findPair(mapping *yaml.Node, key string) *nodePair {
+	for _, pair := range mappingPairs(mapping) {
+		if pair.key.Value == key {
+			return &pair
+		}
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func markOrigin(origins map[*yaml.Node]string, node *yaml.Node, file string) {
+	if _, ok := origins[node]; ok {
+		return
+	}
+	origins[node] = file
+
+	for _, child := range node.Content {
+		markOrigin(origins, child, file)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const includeMain = `include:
+  - conf.d/*.yaml
+inputs:
+  version:
+    type: string
+github:
+  repository: org/repo
+  workflows:
+    build:
+      file: build.yml
+matrix:
+  app:
+    android:
+      prod:
+        workflow: build
+`
+
+// writeConfig writes the main configuration and its fragments to a
+// temporary directory and returns the path of the main file.
+func writeConfig(t *testing.T, main string, fragments map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{"catalyst.yaml": main}
+	for name, content := range fragments {
+		files[filepath.Join("conf.d", name)] = content
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return filepath.Join(dir, "catalyst.yaml")
+}
+
+func TestIncludeProblems(t *testing.T) {
+	tests := []struct {
+		name      string
+		fragments map[string]string
+		want      []Problem
+	}{
+		{
+			name: "new entries",
+			fragments: map[string]string{"app.yaml": `inputs:
+  build:
+    type: number
+matrix:
+  app:
+    android:
+      staging:
+        workflow: build
+`},
+		},
+		{
+			name:      "no matching files",
+			fragments: nil,
+			want: []Problem{{Severity: SeverityWarning, File: "catalyst.yaml", Path: "include", Line: 2, Column: 5,
+				Message: "include pattern conf.d/*.yaml matches no files"}},
+		},
+		{
+			name: "input defined twice",
+			fragments: map[string]string{"app.yaml": `inputs:
+  version:
+    type: number
+`},
+			want: []Problem{{Severity: SeverityError, File: "conf.d/app.yaml", Path: "inputs.version", Line: 2, Column: 3,
+				Message: "inputs.version is already defined in catalyst.yaml:4"}},
+		},
+		{
+			name: "workflow defined twice",
+			fragments: map[string]string{"app.yaml": `github:
+  workflows:
+    build:
+      file: other.yml
+`},
+			want: []Problem{{Severity: SeverityError, File: "conf.d/app.yaml", Path: "github.workflows.build", Line: 3, Column: 5,
+				Message: "github.workflows.build is already defined in catalyst.yaml:9"}},
+		},
+		{
+			name: "environment defined twice",
+			fragments: map[string]string{"app.yaml": `matrix:
+  app:
+    android:
+      prod:
+        workflow: build
+`},
+			want: []Problem{{Severity: SeverityError, File: "conf.d/app.yaml", Path: "matrix.app.android.prod", Line: 4, Column: 7,
+				Message: "matrix.app.android.prod is already defined in catalyst.yaml:14"}},
+		},
+		{
+			name: "environment defined in two fragments",
+			fragments: map[string]string{
+				"a.yaml": `matrix:
+  web:
+    browser:
+      prod:
+        workflow: build
+`,
+				"b.yaml": `matrix:
+
+  web:
+    browser:
+      prod:
+        workflow: build
+`,
+			},
+			want: []Problem{{Severity: SeverityError, File: "conf.d/b.yaml", Path: "matrix.web.browser.prod", Line: 5, Column: 7,
+				Message: "matrix.web.browser.prod is already defined in conf.d/a.yaml:4"}},
+		},
+		{
+			name: "section not allowed",
+			fragments: map[string]string{"app.yaml": `changelog:
+  from: tag
+`},
+			want: []Problem{{Severity: SeverityError, File: "conf.d/app.yaml", Path: "changelog", Line: 1, Column: 1,
+				Message: "changelog cannot be set in an included file (only inputs, presets, matrix and github.workflows)"}},
+		},
+		{
+			name: "github setting not allowed",
+			fragments: map[string]string{"app.yaml": `github:
+  repository: other/repo
+`},
+			want: []Problem{{Severity: SeverityError, File: "conf.d/app.yaml", Path: "github.repository", Line: 2, Column: 3,
+				Message: "github.repository cannot be set in an included file (only github.workflows)"}},
+		},
+		{
+			name: "extension keys",
+			fragments: map[string]string{"app.yaml": `x-prod: &prod
+  workflow: build
+matrix:
+  web:
+    browser:
+      prod: *prod
+`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, includeMain, tt.fragments)
+			dir := filepath.Dir(path) + string(filepath.Separator)
+
+			doc, err := loadDocument(path)
+			if err != nil {
+				t.Fatalf("loadDocument: %v", err)
+			}
+
+			var got []Problem
+			for _, problem := range doc.problems {
+				problem.File = strings.TrimPrefix(problem.File, dir)
+				problem.Message = strings.ReplaceAll(problem.Message, dir, "")
+				got = append(got, problem)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problems = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludeMerges(t *testing.T) {
+	path := writeConfig(t, includeMain, map[string]string{
+		"android.yaml": `matrix:
+  app:
+    android:
+      staging:
+        workflow: build
+`,
+		"ios.yaml": `inputs:
+  build:
+    type: number
+github:
+  workflows:
+    ios:
+      file: ios.yml
+matrix:
+  app:
+    ios:
+      prod:
+        workflow: ios
+`,
+	})
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got, want := sortedKeys(cfg.Matrix["app"]), []string{"android", "ios"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("platforms = %v, want %v", got, want)
+	}
+	if got, want := sortedKeys(cfg.Matrix["app"]["android"]), []string{"prod", "staging"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("android environments = %v, want %v", got, want)
+	}
+	if got, want := sortedKeys(cfg.Inputs), []string{"build", "version"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inputs = %v, want %v", got, want)
+	}
+	if got, want := sortedKeys(cfg.GitHub.Workflows), []string{"build", "ios"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("workflows = %v, want %v", got, want)
+	}
+	if cfg.GitHub.Repository != "org/repo" {
+		t.Errorf("repository = %q, want org/repo", cfg.GitHub.Repository)
+	}
+}
+
+func TestIncludeKeepsAnchors(t *testing.T) {
+	main := `include:
+  - conf.d/*.yaml
+github:
+  repository: org/repo
+  workflows:
+    build:
+      file: build.yml
+x-mobile: &mobile
+  android:
+    prod:
+      workflow: build
+matrix:
+  first: *mobile
+  second: *mobile
+`
+	path := writeConfig(t, main, map[string]string{"first.yaml": `matrix:
+  first:
+    android:
+      staging:
+        workflow: build
+`})
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got, want := sortedKeys(cfg.Matrix["first"]["android"]), []string{"prod", "staging"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first environments = %v, want %v", got, want)
+	}
+	if got, want := sortedKeys(cfg.Matrix["second"]["android"]), []string{"prod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second environments = %v, want %v", got, want)
+	}
+}
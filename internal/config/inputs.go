@@ -0,0 +1,243 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	InputTypeString  = "string"
+	InputTypeNumber  = "number"
+	InputTypeBoolean = "boolean"
+	InputTypeChoice  = "choice"
+	InputTypeSemver  = "semver"
+)
+
+const (
+	SourceGitTag    = "git-tag"
+	SourceHistory   = "increment-from-history"
+	SourceTimestamp = "timestamp"
+
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+	BumpNone  = "none"
+
+	DefaultTimestampFormat = "200601021504"
+)
+
+var inputSources = []string{SourceGitTag, SourceHistory, SourceTimestamp}
+
+var bumpKinds = []string{BumpPatch, BumpMinor, BumpMajor, BumpNone}
+
+type InputSource struct {
+	Type      string `yaml:"type"`
+	Bump      string `yaml:"bump"`
+	TagPrefix string `yaml:"tag_prefix"`
+	Path      string `yaml:"path"`
+	Step      int64  `yaml:"step"`
+	Format    string `yaml:"format"`
+}
+
+// UnmarshalYAML accepts both "source: timestamp" and the long mapping form.
+func (s *InputSource) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		s.Type = node.Value
+		return nil
+	}
+
+	type plain InputSource
+	return node.Decode((*plain)(s))
+}
+
+var inputTypes = []string{
+	InputTypeString,
+	InputTypeNumber,
+	InputTypeBoolean,
+	InputTypeChoice,
+	InputTypeSemver,
+}
+
+var (
+	semverPattern = regexp.MustCompile(
+		`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+			`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+			`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`,
+	)
+)
+
+func (i InputConfig) InputType() string {
+	if i.Type == "" {
+		return InputTypeString
+	}
+	return strings.ToLower(i.Type)
+}
+
+func (i InputConfig) ValidateValue(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if i.Required {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	}
+
+	switch i.InputType() {
+	case InputTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number, got %q", value)
+		}
+		if i.Min != nil && number < *i.Min {
+			return fmt.Errorf("must be at least %v, got %v", *i.Min, number)
+		}
+		if i.Max != nil && number > *i.Max {
+			return fmt.Errorf("must be at most %v, got %v", *i.Max, number)
+		}
+
+	case InputTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be true or false, got %q", value)
+		}
+
+	case InputTypeChoice:
+		for _, option := range i.Options {
+			if option == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s, got %q", strings.Join(i.Options, ", "), value)
+
+	case InputTypeSemver:
+		if !semverPattern.MatchString(value) {
+			return fmt.Errorf("must be a semantic version like 1.2.3, got %q", value)
+		}
+	}
+
+	if i.Pattern != "" {
+		pattern, err := regexp.Compile(i.Pattern)
+		if err != nil {
+			return fmt.Errorf("has an invalid pattern: %w", err)
+		}
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("must match %s, got %q", i.Pattern, value)
+		}
+	}
+
+	return nil
+}
+
+// TypedValue converts a raw input value into the JSON type declared for the
+// input. Values that do not parse are returned unchanged as strings.
+func (i InputConfig) TypedValue(value string) interface{} {
+	value = strings.TrimSpace(value)
+
+	switch i.InputType() {
+	case InputTypeNumber:
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return number
+		}
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+
+	case InputTypeBoolean:
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+
+	return value
+}
+
+func (c *Config) validateInputDefinition(v *validation, name string, input InputConfig) {
+	path := []string{"inputs", name}
+
+	if !containsString(inputTypes, input.InputType()) {
+		v.add(append(path, "type"), "input %s has unknown type %s (expected one of %s)",
+			name, input.Type, strings.Join(inputTypes, ", "))
+		return
+	}
+
+	if input.InputType() == InputTypeChoice && len(input.Options) == 0 {
+		v.add(path, "input %s of type choice has no options", name)
+	}
+	if input.InputType() != InputTypeChoice && len(input.Options) > 0 {
+		v.add(append(path, "options"), "input %s has options but is not of type choice", name)
+	}
+
+	if (input.Min != nil || input.Max != nil) && input.InputType() != InputTypeNumber {
+		v.add(path, "input %s has min/max but is not of type number", name)
+	}
+	if input.Min != nil && input.Max != nil && *input.Min > *input.Max {
+		v.add(path, "input %s has min greater than max", name)
+	}
+
+	if input.Pattern != "" {
+		if _, err := regexp.Compile(input.Pattern); err != nil {
+			v.add(append(path, "pattern"), "input %s has an invalid pattern: %v", name, err)
+			return
+		}
+	}
+
+	if input.Default != "" {
+		if err := input.ValidateValue(input.Default); err != nil {
+			v.add(append(path, "default"), "input %s default %v", name, err)
+		}
+	}
+
+	c.validateInputSource(v, name, input)
+}
+
+func (c *Config) validateInputSource(v *validation, name string, input InputConfig) {
+	source := input.Source
+	if source.Type == "" {
+		return
+	}
+
+	path := []string{"inputs", name, "source"}
+
+	if !containsString(inputSources, source.Type) {
+		v.add(path, "input %s has unknown source %s (expected one of %s)",
+			name, source.Type, strings.Join(inputSources, ", "))
+		return
+	}
+
+	switch source.Type {
+	case SourceGitTag:
+		if input.InputType() != InputTypeString && input.InputType() != InputTypeSemver {
+			v.add(path, "input %s uses source %s but is not of type semver or string",
+				name, source.Type)
+		}
+		if source.Bump != "" && !containsString(bumpKinds, source.Bump) {
+			v.add(path, "input %s has unknown bump %s (expected one of %s)",
+				name, source.Bump, strings.Join(bumpKinds, ", "))
+		}
+
+	case SourceHistory:
+		if input.InputType() != InputTypeString && input.InputType() != InputTypeNumber {
+			v.add(path, "input %s uses source %s but is not of type number or string",
+				name, source.Type)
+		}
+	}
+}
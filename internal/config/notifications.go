@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	NotificationFormatGeneric = "generic"
+	NotificationFormatSlack   = "slack"
+	NotificationFormatTeams   = "teams"
+
+	// NotificationDispatched is sent once the workflows are dispatched,
+	// NotificationCompleted once all of their tracked runs have finished.
+	NotificationDispatched = "dispatched"
+	NotificationCompleted  = "completed"
+)
+
+// NotificationConfig is a webhook that is called when a deployment is
+// dispatched and, if subscribed, when its tracked runs have finished. The
+// url, headers and body are Go templates; without a body, the default body
+// of the format is sent.
+type NotificationConfig struct {
+	URL     string            `yaml:"url"`
+	Format  string            `yaml:"format"`
+	Events  []string          `yaml:"events"`
+	Headers map[string]string `yaml:"headers"`
+	Body    string            `yaml:"body"`
+}
+
+// NotificationFuncs are the functions available to notification templates.
+var NotificationFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"join": func(values []string, separator string) string {
+		return strings.Join(values, separator)
+	},
+	"env": os.Getenv,
+}
+
+// ParseNotificationTemplate parses a url, header or body template.
+func ParseNotificationTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(NotificationFuncs).Option("missingkey=error").Parse(text)
+}
+
+// Subscribes reports whether the webhook is called for the event. Without
+// events, it is only called on dispatch.
+func (n NotificationConfig) Subscribes(event string) bool {
+	if len(n.Events) == 0 {
+		return event == NotificationDispatched
+	}
+	for _, subscribed := range n.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationsFor returns the names of the webhooks called for the event.
+func (c *Config) NotificationsFor(event string) []string {
+	var names []string
+	for _, name := range sortedKeys(c.Notifications) {
+		if c.Notifications[name].Subscribes(event) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (c *Config) validateNotifications(v *validation) {
+	for _, name := range sortedKeys(c.Notifications) {
+		notification := c.Notifications[name]
+		path := []string{"notifications", name}
+
+		if strings.TrimSpace(notification.URL) == "" {
+			v.add(path, "notification %s needs a url", name)
+		} else if _, err := ParseNotificationTemplate("url", notification.URL); err != nil {
+			v.add(append(path, "url"), "notification %s: %v", name, err)
+		}
+
+		switch notification.Format {
+		case "", NotificationFormatGeneric, NotificationFormatSlack, NotificationFormatTeams:
+		default:
+			v.add(append(path, "format"), "notification %s has unknown format %s (expected %s, %s or %s)",
+				name, notification.Format,
+				NotificationFormatGeneric, NotificationFormatSlack, NotificationFormatTeams)
+		}
+
+		for _, event := range notification.Events {
+			if event != NotificationDispatched && event != NotificationCompleted {
+				v.add(append(path, "events"), "notification %s has unknown event %s (expected %s or %s)",
+					name, event, NotificationDispatched, NotificationCompleted)
+			}
+		}
+
+		for _, header := range sortedKeys(notification.Headers) {
+			if _, err := ParseNotificationTemplate(header, notification.Headers[header]); err != nil {
+				v.add(append(path, "headers", header), "notification %s header %s: %v", name, header, err)
+			}
+		}
+
+		if _, err := ParseNotificationTemplate("body", notification.Body); err != nil {
+			v.add(append(path, "body"), "notification %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/constants"
+)
+
+const (
+	NamespaceInputs      = "inputs"
+	NamespaceEnv         = "env"
+	NamespaceGit         = "git"
+	NamespaceNow         = "now"
+	NamespaceApp         = "app"
+	NamespacePlatform    = "platform"
+	NamespaceEnvironment = "environment"
+
+	GitSHA    = "sha"
+	GitBranch = "branch"
+
+	// DefaultNowFormat is used by {{now}} when no layout is given.
+	DefaultNowFormat = time.RFC3339
+)
+
+var placeholderPattern = regexp.MustCompile(constants.RegexPlaceholder)
+
+// PlaceholderContext holds the values placeholders are resolved from.
+type PlaceholderContext struct {
+	Inputs      map[string]string
+	App         string
+	Platform    string
+	Environment string
+	Branch      string
+	// Commits maps refs to their commit; {{git.sha}} is the commit of
+	// Branch.
+	Commits map[string]string
+	Now     time.Time
+	// RedactEnv resolves {{env.NAME}} placeholders of variables that are
+	// set to RedactedValue, for what Catalyst writes to disk.
+	RedactEnv bool
+}
+
+// Placeholder is a parsed {{...}} expression. Name is the input or
+// environment variable name, the git field or the time layout.
+type Placeholder struct {
+	Namespace string
+	Name      string
+}
+
+// ParsePlaceholder parses the expression between the braces of a placeholder.
+func ParsePlaceholder(expression string) (Placeholder, error) {
+	expression = strings.TrimSpace(expression)
+
+	if expression == NamespaceNow || strings.HasPrefix(expression, NamespaceNow+" ") {
+		layout := strings.TrimSpace(strings.TrimPrefix(expression, NamespaceNow))
+		if layout == "" {
+			return Placeholder{Namespace: NamespaceNow, Name: DefaultNowFormat}, nil
+		}
+
+		unquoted, err := strconv.Unquote(layout)
+		if err != nil || unquoted == "" {
+			return Placeholder{}, fmt.Errorf("now expects a quoted time layout, e.g. {{now \"2006-01-02\"}}")
+		}
+		return Placeholder{Namespace: NamespaceNow, Name: unquoted}, nil
+	}
+
+	switch expression {
+	case NamespaceApp, NamespacePlatform, NamespaceEnvironment:
+		return Placeholder{Namespace: expression}, nil
+	}
+
+	namespace, name, found := strings.Cut(expression, ".")
+	if !found || name == "" {
+		return Placeholder{}, fmt.Errorf("unknown placeholder {{%s}}", expression)
+	}
+
+	switch namespace {
+	case NamespaceInputs, NamespaceEnv:
+		return Placeholder{Namespace: namespace, Name: name}, nil
+	case NamespaceGit:
+		if name != GitSHA && name != GitBranch {
+			return Placeholder{}, fmt.Errorf("unknown git field %s (expected %s or %s)", name, GitSHA, GitBranch)
+		}
+		return Placeholder{Namespace: namespace, Name: name}, nil
+	}
+
+	return Placeholder{}, fmt.Errorf("unknown placeholder namespace %s", namespace)
+}
+
+// Placeholders returns the placeholders used in value. Expressions that cannot
+// be parsed are returned as errors.
+func Placeholders(value string) ([]Placeholder, []error) {
+	var placeholders []Placeholder
+	var errs []error
+
+	for _, match := range placeholderPattern.FindAllStringSubmatch(value, -1) {
+		placeholder, err := ParsePlaceholder(match[1])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		placeholders = append(placeholders, placeholder)
+	}
+
+	return placeholders, errs
+}
+
+func InputReferences(value string) []string {
+	var names []string
+	placeholders, _ := Placeholders(value)
+	for _, placeholder := range placeholders {
+		if placeholder.Namespace == NamespaceInputs {
+			names = append(names, placeholder.Name)
+		}
+	}
+	return names
+}
+
+// UsesPlaceholder reports whether value references the given namespace and,
+// if name is not empty, the given name within it.
+func UsesPlaceholder(value string, namespace string, name string) bool {
+	placeholders, _ := Placeholders(value)
+	for _, placeholder := range placeholders {
+		if placeholder.Namespace == namespace && (name == "" || placeholder.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// SubstituteVariables replaces every placeholder in value. Placeholders that
+// cannot be resolved are left untouched.
+func (c *Config) SubstituteVariables(value string, ctx PlaceholderContext) string {
+	return placeholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+		placeholder, err := ParsePlaceholder(placeholderPattern.FindStringSubmatch(match)[1])
+		if err != nil {
+			return match
+		}
+
+		if resolved, ok := c.resolvePlaceholder(placeholder, ctx); ok {
+			return resolved
+		}
+		return match
+	})
+}
+
+func (c *Config) resolvePlaceholder(placeholder Placeholder, ctx PlaceholderContext) (string, bool) {
+	switch placeholder.Namespace {
+	case NamespaceInputs:
+		if val, ok := ctx.Inputs[placeholder.Name]; ok {
+			return val, true
+		}
+		if input, ok := c.Inputs[placeholder.Name]; ok {
+			return input.Default, true
+		}
+		return "", false
+	case NamespaceEnv:
+		value, ok := os.LookupEnv(placeholder.Name)
+		if ok && ctx.RedactEnv {
+			return RedactedValue, true
+		}
+		return value, ok
+	case NamespaceGit:
+		if placeholder.Name == GitBranch {
+			return ctx.Branch, ctx.Branch != ""
+		}
+		commit := ctx.Commits[ctx.Branch]
+		return commit, commit != ""
+	case NamespaceNow:
+		now := ctx.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		return now.UTC().Format(placeholder.Name), true
+	case NamespaceApp:
+		return ctx.App, true
+	case NamespacePlatform:
+		return ctx.Platform, true
+	case NamespaceEnvironment:
+		return ctx.Environment, true
+	}
+	return "", false
+}
+
+// ResolveValue substitutes the placeholders in a matrix value. A value that
+// consists of a single input placeholder takes on the type of the input.
+func (c *Config) ResolveValue(value string, ctx PlaceholderContext) interface{} {
+	substituted := c.SubstituteVariables(value, ctx)
+
+	if match := placeholderPattern.FindStringSubmatch(value); match != nil && match[0] == value {
+		placeholder, err := ParsePlaceholder(match[1])
+		if err != nil || placeholder.Namespace != NamespaceInputs {
+			return substituted
+		}
+		if input, ok := c.Inputs[placeholder.Name]; ok && substituted != value {
+			return input.TypedValue(substituted)
+		}
+	}
+
+	return substituted
+}
+
+// RedactedValue replaces the values of {{env.NAME}} placeholders, which may
+// be secrets, in the history, the audit log and dry-run payloads.
+const RedactedValue = "***"
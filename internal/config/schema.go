@@ -0,0 +1,373 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed schema.json
+var schemaJSON []byte
+
+func Schema() []byte {
+	return schemaJSON
+}
+
+// schema is the subset of JSON Schema (draft-07) used by schema.json. It is
+// only meant to check catalyst.yaml; it is not a general purpose validator.
+type schema struct {
+	Type                 schemaTypes        `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	PatternProperties    map[string]*schema `json:"patternProperties"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	Enum                 []string           `json:"enum"`
+	Items                *schema            `json:"items"`
+	MinProperties        *int               `json:"minProperties"`
+	MinLength            *int               `json:"minLength"`
+	Pattern              string             `json:"pattern"`
+	Ref                  string             `json:"$ref"`
+	OneOf                []*schema          `json:"oneOf"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*t = multiple
+	return nil
+}
+
+type schemaValidator struct {
+	root     *schema
+	document *document
+	problems []Problem
+}
+
+func validateSchema(doc *document) ([]Problem, error) {
+	var root schema
+	if err := json.Unmarshal(schemaJSON, &root); err != nil {
+		return nil, fmt.Errorf("invalid embedded schema: %w", err)
+	}
+
+	validator := &schemaValidator{root: &root, document: doc}
+
+	node := doc.root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return []Problem{{
+				Severity: SeverityError,
+				File:     doc.file,
+				Line:     1,
+				Column:   1,
+				Message:  "configuration is empty",
+			}}, nil
+		}
+		node = node.Content[0]
+	}
+
+	validator.validate(&root, node, nil)
+	return validator.problems, nil
+}
+
+func (v *schemaValidator) report(node *yaml.Node, path []string, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{
+		Severity: SeverityError,
+		File:     v.document.fileOf(node),
+		Path:     strings.Join(path, "."),
+		Line:     node.Line,
+		Column:   node.Column,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *schemaValidator) resolve(s *schema) *schema {
+	for s != nil && s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		s = v.root.Definitions[name]
+	}
+	return s
+}
+
+func (v *schemaValidator) validate(s *schema, node *yaml.Node, path []string) {
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if len(s.OneOf) > 0 {
+		v.validateOneOf(s.OneOf, node, path)
+		return
+	}
+
+	if len(s.Type) > 0 && !matchesType(s.Type, node) {
+		v.report(node, path, "expected %s, got %s", strings.Join(s.Type, " or "), nodeType(node))
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(s, node, path)
+
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range node.Content {
+				v.validate(s.Items, item, append(path, fmt.Sprintf("%d", i)))
+			}
+		}
+
+	case yaml.ScalarNode:
+		if len(s.Enum) > 0 && !containsString(s.Enum, node.Value) {
+			v.report(node, path, "must be one of %s, got %q", strings.Join(s.Enum, ", "), node.Value)
+		}
+		if s.MinLength != nil && len(node.Value) < *s.MinLength {
+			v.report(node, path, "must not be empty")
+		}
+		if s.Pattern != "" {
+			if pattern, err := regexp.Compile(s.Pattern); err == nil &&
+				!pattern.MatchString(node.Value) {
+				v.report(node, path, "%q does not match pattern %s", node.Value, s.Pattern)
+			}
+		}
+	}
+}
+
+// validateOneOf accepts the node when any alternative matches. Otherwise it
+// reports the problems of the first alternative with a matching type.
+func (v *schemaValidator) validateOneOf(alternatives []*schema, node *yaml.Node, path []string) {
+	var candidate []Problem
+	var types []string
+
+	for _, alternative := range alternatives {
+		alternative = v.resolve(alternative)
+		if alternative == nil {
+			continue
+		}
+
+		sub := &schemaValidator{root: v.root, document: v.document}
+		sub.validate(alternative, node, path)
+		if len(sub.problems) == 0 {
+			return
+		}
+
+		types = append(types, alternative.Type...)
+		if candidate == nil && (len(alternative.Type) == 0 || matchesType(alternative.Type, node)) {
+			candidate = sub.problems
+		}
+	}
+
+	if candidate != nil {
+		v.problems = append(v.problems, candidate...)
+		return
+	}
+	v.report(node, path, "expected %s, got %s", strings.Join(types, " or "), nodeType(node))
+}
+
+func (v *schemaValidator) validateMapping(s *schema, node *yaml.Node, path []string) {
+	pairs := mappingPairs(node)
+
+	if s.MinProperties != nil && len(pairs) < *s.MinProperties {
+		v.report(node, path, "must have at least %d entries", *s.MinProperties)
+	}
+
+	present := make(map[string]bool)
+	for _, pair := range pairs {
+		present[pair.key.Value] = true
+	}
+
+	for _, required := range s.Required {
+		if !present[required] {
+			v.report(node, path, "missing required key %q", required)
+		}
+	}
+
+	var additional *schema
+	allowAdditional := true
+	if len(s.AdditionalProperties) > 0 {
+		var flag bool
+		if err := json.Unmarshal(s.AdditionalProperties, &flag); err == nil {
+			allowAdditional = flag
+		} else {
+			additional = &schema{}
+			if err := json.Unmarshal(s.AdditionalProperties, additional); err != nil {
+				additional = nil
+			}
+		}
+	}
+
+	for _, pair := range pairs {
+		key := pair.key.Value
+		childPath := append(append([]string{}, path...), key)
+
+		if property, ok := s.Properties[key]; ok {
+			v.validate(property, pair.value, childPath)
+			continue
+		}
+
+		if property := s.patternProperty(key); property != nil {
+			v.validate(property, pair.value, childPath)
+			continue
+		}
+
+		if additional != nil {
+			v.validate(additional, pair.value, childPath)
+			continue
+		}
+
+		if !allowAdditional {
+			known := make([]string, 0, len(s.Properties))
+			for name := range s.Properties {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			v.report(pair.key, childPath, "unknown key %q (expected one of %s)",
+				key, strings.Join(known, ", "))
+		}
+	}
+}
+
+// patternProperty returns the schema of the first patternProperties entry
+// matching key, in pattern order so the result is deterministic.
+func (s *schema) patternProperty(key string) *schema {
+	patterns := make([]string, 0, len(s.PatternProperties))
+	for pattern := range s.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if re, err := regexp.Compile(pattern); err == nil && re.MatchString(key) {
+			return s.PatternProperties[pattern]
+		}
+	}
+	return nil
+}
+
+type nodePair struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// mappingPairs returns the key/value pairs of a mapping with YAML merge keys
+// (<<: *anchor) expanded, so anchors validate like inline content.
+func mappingPairs(node *yaml.Node) []nodePair {
+	var pairs []nodePair
+	seen := make(map[string]bool)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value != "<<" {
+			pairs = append(pairs, nodePair{key: key, value: value})
+			seen[key.Value] = true
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value != "<<" {
+			continue
+		}
+
+		var merged []*yaml.Node
+		if value.Kind == yaml.SequenceNode {
+			merged = value.Content
+		} else {
+			merged = []*yaml.Node{value}
+		}
+
+		for _, source := range merged {
+			for source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind != yaml.MappingNode {
+				continue
+			}
+			for _, pair := range mappingPairs(source) {
+				if !seen[pair.key.Value] {
+					pairs = append(pairs, pair)
+					seen[pair.key.Value] = true
+				}
+			}
+		}
+	}
+
+	return pairs
+}
+
+func matchesType(types []string, node *yaml.Node) bool {
+	actual := nodeType(node)
+	for _, expected := range types {
+		if expected == actual || (expected == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!int":
+			return "integer"
+		case "!!float":
+			return "number"
+		case "!!bool":
+			return "boolean"
+		case "!!null":
+			return "null"
+		default:
+			return "string"
+		}
+	default:
+		return "unknown"
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
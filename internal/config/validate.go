@@ -0,0 +1,389 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+type Problem struct {
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (p Problem) String() string {
+	var location strings.Builder
+	if p.File != "" {
+		location.WriteString(p.File)
+		if p.Line > 0 {
+			location.WriteString(fmt.Sprintf(":%d:%d", p.Line, p.Column))
+		}
+		location.WriteString(": ")
+	}
+	if p.Severity == SeverityWarning {
+		location.WriteString("warning: ")
+	}
+	if p.Path != "" {
+		location.WriteString(p.Path + ": ")
+	}
+	return location.String() + p.Message
+}
+
+func (p Problem) IsError() bool {
+	return p.Severity != SeverityWarning
+}
+
+type validation struct {
+	config   *Config
+	problems []Problem
+}
+
+func (v *validation) add(path []string, format string, args ...interface{}) {
+	v.report(SeverityError, path, format, args...)
+}
+
+func (v *validation) warn(path []string, format string, args ...interface{}) {
+	v.report(SeverityWarning, path, format, args...)
+}
+
+func (v *validation) report(severity string, path []string, format string, args ...interface{}) {
+	file, line, column := v.config.position(path)
+	v.problems = append(v.problems, Problem{
+		Severity: severity,
+		File:     file,
+		Path:     strings.Join(path, "."),
+		Line:     line,
+		Column:   column,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *Config) Validate() error {
+	for _, problem := range c.Problems() {
+		if !problem.IsError() {
+			continue
+		}
+		// Problems in included files name the file they come from.
+		if problem.File != "" && problem.File != c.file {
+			return fmt.Errorf("%s:%d: %s", problem.File, problem.Line, problem.Message)
+		}
+		return errors.New(problem.Message)
+	}
+	return nil
+}
+
+func (c *Config) Problems() []Problem {
+	v := &validation{config: c}
+	v.problems = append(v.problems, c.includeProblems...)
+
+	c.validateGitHub(v)
+	c.validateMatrix(v)
+	c.validateAllowedRefs(v)
+	c.validateInputs(v)
+	c.validateWorkflowInputs(v)
+	c.validatePresets(v)
+	c.validateChangelog(v)
+	c.validateFreeze(v)
+	c.validateNotifications(v)
+
+	sortProblems(v.problems)
+	return v.problems
+}
+
+func (c *Config) validateGitHub(v *validation) {
+	if c.GitHub.Repository == "" {
+		v.add([]string{"github", "repository"}, "GitHub repository is required")
+	}
+
+	switch strings.ToLower(c.GitHub.Backend) {
+	case "", "auto", "rest", "gh":
+	default:
+		v.add([]string{"github", "backend"},
+			"GitHub backend must be one of auto, rest or gh, got %s", c.GitHub.Backend)
+	}
+
+	if c.GitHub.Dispatch.Concurrency < 0 {
+		v.add([]string{"github", "dispatch", "concurrency"}, "dispatch concurrency must not be negative")
+	}
+	if c.GitHub.Dispatch.MaxAttempts < 0 {
+		v.add([]string{"github", "dispatch", "max_attempts"}, "dispatch max_attempts must not be negative")
+	}
+
+	if len(c.GitHub.Workflows) == 0 {
+		v.add([]string{"github", "workflows"}, "at least one workflow is required")
+	}
+}
+
+func (c *Config) validateMatrix(v *validation) {
+	if len(c.Matrix) == 0 {
+		v.add([]string{"matrix"}, "at least one app is required in matrix")
+	}
+
+	for _, app := range sortedKeys(c.Matrix) {
+		platforms := c.Matrix[app]
+		if len(platforms) == 0 {
+			v.add([]string{"matrix", app}, "app %s has no platforms", app)
+		}
+
+		for _, platform := range sortedKeys(platforms) {
+			environments := platforms[platform]
+			if len(environments) == 0 {
+				v.add([]string{"matrix", app, platform},
+					"app %s platform %s has no environments", app, platform)
+			}
+
+			for _, env := range sortedKeys(environments) {
+				config := environments[env]
+				path := []string{"matrix", app, platform, env, "workflow"}
+
+				if config.Workflow == "" {
+					v.add(path, "app %s platform %s environment %s has no workflow",
+						app, platform, env)
+					continue
+				}
+
+				if _, ok := c.GitHub.Workflows[config.Workflow]; !ok {
+					v.add(path, "app %s platform %s environment %s references unknown workflow %s",
+						app, platform, env, config.Workflow)
+				}
+			}
+		}
+	}
+}
+
+func (c *Config) validateInputs(v *validation) {
+	referenced := make(map[string]bool)
+
+	for _, app := range sortedKeys(c.Matrix) {
+		for _, platform := range sortedKeys(c.Matrix[app]) {
+			for _, env := range sortedKeys(c.Matrix[app][platform]) {
+				matrix := c.Matrix[app][platform][env].Matrix
+
+				for _, key := range sortedKeys(matrix) {
+					strValue, ok := matrix[key].(string)
+					if !ok {
+						continue
+					}
+
+					path := []string{"matrix", app, platform, env, "matrix", key}
+
+					_, errs := Placeholders(strValue)
+					for _, err := range errs {
+						v.add(path, "app %s platform %s environment %s: %v", app, platform, env, err)
+					}
+
+					for _, name := range InputReferences(strValue) {
+						referenced[name] = true
+
+						if _, ok := c.Inputs[name]; !ok {
+							v.add(path,
+								"app %s platform %s environment %s references undefined input %s",
+								app, platform, env, name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, workflow := range c.GitHub.Workflows {
+		for _, name := range workflow.InputReferences() {
+			referenced[name] = true
+		}
+	}
+
+	for _, name := range sortedKeys(c.Inputs) {
+		input := c.Inputs[name]
+		path := []string{"inputs", name}
+
+		c.validateInputDefinition(v, name, input)
+
+		if !referenced[name] {
+			v.warn(path, "input %s is declared but never referenced in matrix or workflow inputs", name)
+			continue
+		}
+
+		if input.Required && strings.TrimSpace(input.Default) == "" {
+			v.warn(path, "input %s is required and has no default; "+
+				"headless triggers must pass --input %s=<value>", name, name)
+		}
+	}
+}
+
+func (c *Config) validatePresets(v *validation) {
+	for _, name := range c.GetPresets() {
+		preset := c.Presets[name]
+		path := []string{"presets", name}
+
+		if len(preset.Apps) == 0 && len(preset.Platforms) == 0 && len(preset.Environments) == 0 {
+			v.add(path, "preset %s selects no apps, platforms or environments", name)
+			continue
+		}
+
+		if _, err := c.ResolvePreset(name); err != nil {
+			v.add(path, "%s", err.Error())
+		}
+
+		for _, input := range sortedKeys(preset.Inputs) {
+			inputConfig, ok := c.Inputs[input]
+			if !ok {
+				v.add(append(path, "inputs", input),
+					"preset %s references unknown input %s", name, input)
+				continue
+			}
+
+			if err := inputConfig.ValidateValue(preset.Inputs[input]); err != nil {
+				v.add(append(path, "inputs", input),
+					"preset %s input %s %v", name, input, err)
+			}
+		}
+	}
+}
+
+func (c *Config) validateChangelog(v *validation) {
+	if c.Changelog.MaxLength < 0 {
+		v.add([]string{"changelog", "max_length"}, "changelog max_length must not be negative")
+	}
+
+	apps := make(map[string]bool)
+	for _, app := range c.GetApps() {
+		apps[app] = true
+	}
+
+	for _, app := range sortedKeys(c.Changelog.Paths) {
+		if !apps[app] {
+			v.add([]string{"changelog", "paths", app},
+				"changelog paths reference unknown app %s", app)
+		}
+	}
+}
+
+// ValidateFile reports every schema and semantic problem in the configuration
+// file instead of stopping at the first one.
+func ValidateFile(path string) ([]Problem, error) {
+	path = ResolvePath(path)
+
+	doc, err := loadDocument(path)
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := validateSchema(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := doc.root.Decode(&config); err != nil {
+		// Type errors are already reported by the schema with their position.
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+	config.setDocument(doc)
+
+	reported := make(map[string]bool)
+	for _, problem := range problems {
+		reported[fmt.Sprintf("%s:%d:%d", problem.File, problem.Line, problem.Column)] = true
+	}
+
+	for _, problem := range config.Problems() {
+		if problem.Line > 0 &&
+			reported[fmt.Sprintf("%s:%d:%d", problem.File, problem.Line, problem.Column)] {
+			continue
+		}
+		problems = append(problems, problem)
+	}
+
+	sortProblems(problems)
+	return problems, nil
+}
+
+// position returns the file, line and column of the deepest node found
+// along path.
+func (c *Config) position(path []string) (string, int, int) {
+	if c.root == nil {
+		return c.file, 0, 0
+	}
+
+	node := c.root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, key := range path {
+		for node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+
+		var next *yaml.Node
+		for _, pair := range mappingPairs(node) {
+			if pair.key.Value == key {
+				next = pair.value
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+
+	if file, ok := c.origins[node]; ok {
+		return file, node.Line, node.Column
+	}
+	return c.file, node.Line, node.Column
+}
+
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"sort"
+)
+
+const (
+	DefaultPayloadInput   = "payload"
+	DefaultChangeLogInput = "change_log"
+)
+
+// WorkflowInputsConfig describes how the workflow_dispatch inputs of a
+// workflow are built. Payload and ChangeLog rename the default inputs; an
+// empty name leaves the input out. Fields sends matrix fields as inputs of
+// their own and Templates renders inputs from placeholders.
+type WorkflowInputsConfig struct {
+	Payload   *string           `yaml:"payload"`
+	ChangeLog *string           `yaml:"change_log"`
+	Fields    map[string]string `yaml:"fields"`
+	Templates map[string]string `yaml:"templates"`
+}
+
+func (w WorkflowInputsConfig) PayloadInput() string {
+	if w.Payload == nil {
+		return DefaultPayloadInput
+	}
+	return *w.Payload
+}
+
+func (w WorkflowInputsConfig) ChangeLogInput() string {
+	if w.ChangeLog == nil {
+		return DefaultChangeLogInput
+	}
+	return *w.ChangeLog
+}
+
+// InputReferences returns the inputs referenced by the templates of the
+// workflow, sorted.
+func (w WorkflowConfig) InputReferences() []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, template := range w.Inputs.Templates {
+		for _, name := range InputReferences(template) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (c *Config) validateWorkflowInputs(v *validation) {
+	for _, key := range sortedKeys(c.GitHub.Workflows) {
+		inputs := c.GitHub.Workflows[key].Inputs
+		path := []string{"github", "workflows", key, "inputs"}
+
+		split := c.GitHub.Workflows[key].Split
+		if split.MaxPayloadBytes < 0 {
+			v.add([]string{"github", "workflows", key, "split", "max_payload_bytes"},
+				"workflow %s max_payload_bytes must not be negative", key)
+		}
+		if split.MaxMatrices < 0 {
+			v.add([]string{"github", "workflows", key, "split", "max_matrices"},
+				"workflow %s max_matrices must not be negative", key)
+		}
+
+		names := make(map[string]string)
+		claim := func(name, source string, namePath []string) {
+			if previous, ok := names[name]; ok {
+				v.add(namePath, "workflow %s sets input %s from both %s and %s",
+					key, name, previous, source)
+				return
+			}
+			names[name] = source
+		}
+
+		if name := inputs.PayloadInput(); name != "" {
+			claim(name, "payload", append(path, "payload"))
+		}
+		if name := inputs.ChangeLogInput(); name != "" {
+			claim(name, "change_log", append(path, "change_log"))
+		}
+
+		for _, name := range sortedKeys(inputs.Fields) {
+			fieldPath := append(append([]string{}, path...), "fields", name)
+			claim(name, "fields", fieldPath)
+
+			if inputs.Fields[name] == "" {
+				v.add(fieldPath, "workflow %s input %s maps an empty matrix field", key, name)
+				continue
+			}
+			c.validateWorkflowField(v, key, inputs.Fields[name], fieldPath)
+		}
+
+		for _, name := range sortedKeys(inputs.Templates) {
+			templatePath := append(append([]string{}, path...), "templates", name)
+			claim(name, "templates", templatePath)
+
+			placeholders, errs := Placeholders(inputs.Templates[name])
+			for _, err := range errs {
+				v.add(templatePath, "workflow %s input %s: %v", key, name, err)
+			}
+
+			for _, placeholder := range placeholders {
+				switch placeholder.Namespace {
+				case NamespaceApp, NamespacePlatform, NamespaceEnvironment:
+					v.add(templatePath,
+						"workflow %s input %s: {{%s}} is only available in matrix values",
+						key, name, placeholder.Namespace)
+				case NamespaceInputs:
+					if _, ok := c.Inputs[placeholder.Name]; !ok {
+						v.add(templatePath, "workflow %s input %s references undefined input %s",
+							key, name, placeholder.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// validateWorkflowField checks that every environment dispatching the
+// workflow defines the mapped matrix field.
+func (c *Config) validateWorkflowField(v *validation, workflow, field string, path []string) {
+	for _, app := range sortedKeys(c.Matrix) {
+		for _, platform := range sortedKeys(c.Matrix[app]) {
+			for _, env := range sortedKeys(c.Matrix[app][platform]) {
+				envConfig := c.Matrix[app][platform][env]
+				if envConfig.Workflow != workflow {
+					continue
+				}
+
+				if _, ok := envConfig.Matrix[field]; !ok {
+					v.add(path, "workflow %s maps matrix field %s, which app %s platform %s environment %s does not define",
+						workflow, field, app, platform, env)
+				}
+			}
+		}
+	}
+}
@@ -17,5 +17,5 @@
 package constants
 
 const (
-	RegexInputPlaceholder = `{{inputs\.([^}]+)}}`
+	RegexPlaceholder = `{{\s*([^{}]*?)\s*}}`
 )
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cron parses standard five-field cron expressions
+// (minute hour day-of-month month day-of-week).
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds the search for the next matching minute, so that
+// expressions which never match (e.g. February 30th) terminate.
+const searchLimit = 5
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	dayField    = field{name: "day-of-month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	weekdayField = field{name: "day-of-week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minutes  []bool
+	hours    []bool
+	days     []bool
+	months   []bool
+	weekdays []bool
+
+	// Like cron, a day matches either field when both day-of-month and
+	// day-of-week are restricted, and the restricted one otherwise.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// Parse parses an expression such as "0 18 * * fri" or "*/15 9-17 * * 1-5".
+// Fields accept *, numbers, names for months and weekdays, ranges, steps
+// and comma-separated lists. Sunday is 0 or 7.
+func Parse(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	var s Schedule
+	var err error
+
+	if s.minutes, err = minuteField.parse(fields[0]); err != nil {
+		return Schedule{}, err
+	}
+	if s.hours, err = hourField.parse(fields[1]); err != nil {
+		return Schedule{}, err
+	}
+	if s.days, err = dayField.parse(fields[2]); err != nil {
+		return Schedule{}, err
+	}
+	if s.months, err = monthField.parse(fields[3]); err != nil {
+		return Schedule{}, err
+	}
+	if s.weekdays, err = weekdayField.parse(fields[4]); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.weekdays[7] {
+		s.weekdays[0] = true
+	}
+	s.anyDay = strings.HasPrefix(fields[2], "*")
+	s.anyWeekday = strings.HasPrefix(fields[4], "*")
+
+	return s, nil
+}
+
+func (f field) parse(expr string) ([]bool, error) {
+	values := make([]bool, f.max+1)
+
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid %s step %q", f.name, stepExpr)
+			}
+		}
+
+		first, last := f.min, f.max
+		if rangeExpr != "*" {
+			fromExpr, toExpr, isRange := strings.Cut(rangeExpr, "-")
+
+			var err error
+			if first, err = f.value(fromExpr); err != nil {
+				return nil, err
+			}
+			last = first
+			if isRange {
+				if last, err = f.value(toExpr); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				last = f.max
+			}
+			if first > last {
+				return nil, fmt.Errorf("invalid %s range %q", f.name, rangeExpr)
+			}
+		}
+
+		for value := first; value <= last; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+func (f field) value(expr string) (int, error) {
+	if value, ok := f.names[strings.ToLower(expr)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(expr)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid %s %q (expected %d-%d)", f.name, expr, f.min, f.max)
+	}
+	return value, nil
+}
+
+// Next returns the first minute after t that matches the schedule, in t's
+// location, or the zero time if there is none within five years.
+func (s Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(searchLimit, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !s.months[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !s.hours[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !s.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	day := s.days[t.Day()]
+	weekday := s.weekdays[t.Weekday()]
+
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
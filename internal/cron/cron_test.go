@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// at parses a UTC time such as "2025-01-01 12:00".
+func at(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		t.Fatalf("invalid test time %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestNext(t *testing.T) {
+	// 2025-01-01 is a Wednesday.
+	tests := []struct {
+		name string
+		expr string
+		from string
+		want string
+	}{
+		{name: "every minute", expr: "* * * * *", from: "2025-01-01 12:00", want: "2025-01-01 12:01"},
+		{name: "strictly after", expr: "0 12 * * *", from: "2025-01-01 12:00", want: "2025-01-02 12:00"},
+		{name: "later today", expr: "30 18 * * *", from: "2025-01-01 12:00", want: "2025-01-01 18:30"},
+		{name: "minute list", expr: "10,40 * * * *", from: "2025-01-01 12:15", want: "2025-01-01 12:40"},
+		{name: "hour range", expr: "0 9-17 * * *", from: "2025-01-01 17:30", want: "2025-01-02 09:00"},
+		{name: "step", expr: "*/15 * * * *", from: "2025-01-01 12:16", want: "2025-01-01 12:30"},
+		{name: "range with step", expr: "0 8-18/4 * * *", from: "2025-01-01 12:01", want: "2025-01-01 16:00"},
+		{name: "start with step", expr: "5/20 * * * *", from: "2025-01-01 12:26", want: "2025-01-01 12:45"},
+		{name: "weekday name", expr: "0 18 * * fri", from: "2025-01-01 12:00", want: "2025-01-03 18:00"},
+		{name: "weekday names are case insensitive", expr: "0 18 * * FRI", from: "2025-01-01 12:00", want: "2025-01-03 18:00"},
+		{name: "weekday range", expr: "0 9 * * mon-fri", from: "2025-01-03 10:00", want: "2025-01-06 09:00"},
+		{name: "sunday as 0", expr: "0 0 * * 0", from: "2025-01-01 12:00", want: "2025-01-05 00:00"},
+		{name: "sunday as 7", expr: "0 0 * * 7", from: "2025-01-01 12:00", want: "2025-01-05 00:00"},
+		{name: "range to 7", expr: "0 0 * * 6-7", from: "2025-01-04 12:00", want: "2025-01-05 00:00"},
+		{name: "month name", expr: "0 0 1 mar *", from: "2025-01-01 12:00", want: "2025-03-01 00:00"},
+		{name: "next year", expr: "0 0 1 jan *", from: "2025-01-01 12:00", want: "2026-01-01 00:00"},
+		{name: "day of month", expr: "0 0 31 * *", from: "2025-02-01 00:00", want: "2025-03-31 00:00"},
+		{name: "leap day", expr: "0 0 29 2 *", from: "2025-01-01 00:00", want: "2028-02-29 00:00"},
+		{name: "day of month or weekday, weekday first", expr: "0 0 13 * fri", from: "2025-01-01 12:00", want: "2025-01-03 00:00"},
+		{name: "day of month or weekday, day first", expr: "0 0 13 * fri", from: "2025-01-11 12:00", want: "2025-01-13 00:00"},
+		{name: "stepped day of month is unrestricted", expr: "0 0 */2 * fri", from: "2025-01-03 12:00", want: "2025-01-10 00:00"},
+		{name: "restricted weekday only", expr: "0 0 * * mon", from: "2025-01-01 12:00", want: "2025-01-06 00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+
+			got := schedule.Next(at(t, tt.from))
+			if want := at(t, tt.want); !got.Equal(want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got.Format(time.DateTime), want.Format(time.DateTime))
+			}
+		})
+	}
+}
+
+func TestNextTruncatesSeconds(t *testing.T) {
+	schedule, err := Parse("* * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := schedule.Next(time.Date(2025, 1, 1, 12, 0, 59, 999, time.UTC))
+	if want := time.Date(2025, 1, 1, 12, 1, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next = %s, want %s", got, want)
+	}
+}
+
+func TestNextKeepsLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	schedule, err := Parse("0 18 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := schedule.Next(time.Date(2025, 1, 1, 12, 0, 0, 0, loc))
+	if want := time.Date(2025, 1, 1, 18, 0, 0, 0, loc); !got.Equal(want) || got.Location() != loc {
+		t.Errorf("Next = %s, want %s", got, want)
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	schedule, err := Parse("0 0 30 feb *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := schedule.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("Next = %s, want the zero time", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr     string
+		wantText string
+	}{
+		{expr: "* * * *", wantText: "must have 5 fields, got 4"},
+		{expr: "* * * * * *", wantText: "must have 5 fields, got 6"},
+		{expr: "60 * * * *", wantText: `invalid minute "60" (expected 0-59)`},
+		{expr: "* 24 * * *", wantText: `invalid hour "24"`},
+		{expr: "* * 0 * *", wantText: `invalid day-of-month "0" (expected 1-31)`},
+		{expr: "* * * 13 *", wantText: `invalid month "13"`},
+		{expr: "* * * * 8", wantText: `invalid day-of-week "8" (expected 0-7)`},
+		{expr: "* * * * fri-mon", wantText: `invalid day-of-week range "fri-mon"`},
+		{expr: "* 17-9 * * *", wantText: `invalid hour range "17-9"`},
+		{expr: "*/0 * * * *", wantText: `invalid minute step "0"`},
+		{expr: "*/x * * * *", wantText: `invalid minute step "x"`},
+		{expr: "* * * mon *", wantText: `invalid month "mon"`},
+		{expr: "* * * * jan", wantText: `invalid day-of-week "jan"`},
+		{expr: "1,,2 * * * *", wantText: `invalid minute ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error %q does not contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
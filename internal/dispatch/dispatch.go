@@ -0,0 +1,329 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dispatch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/audit"
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/github"
+)
+
+const (
+	DefaultConcurrency = 4
+	DefaultMaxAttempts = 3
+
+	initialBackoff = time.Second
+	maxBackoff     = 30 * time.Second
+	// maxRetryDelay is the longest rate limit wait Catalyst sits out.
+	maxRetryDelay = 2 * time.Minute
+)
+
+type Request struct {
+	Config    *config.Config
+	Client    github.Client
+	Matrices  map[string][]map[string]interface{}
+	Branch    string
+	ChangeLog string
+
+	// Placeholders resolves the templates of workflow inputs. Its branch is
+	// replaced by the ref of each workflow.
+	Placeholders config.PlaceholderContext
+
+	// Redacted are the Matrices with the values of {{env.NAME}}
+	// placeholders redacted. The audit log and dry-run payloads are built
+	// from them, and from templates with those values redacted too.
+	Redacted map[string][]map[string]interface{}
+
+	// Audit records every dispatch attempt when set.
+	Audit *audit.Log
+}
+
+type Result struct {
+	Workflow     string
+	File         string
+	Repository   string
+	Branch       string
+	Matrices     int
+	Chunk        int
+	Chunks       int
+	Attempts     int
+	DispatchedAt time.Time
+	Error        error
+}
+
+// Part describes which of the workflow's dispatches the result belongs to,
+// e.g. "part 2/3", or is empty when the matrices were not split.
+func (r Result) Part() string {
+	if r.Chunks <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("part %d/%d", r.Chunk, r.Chunks)
+}
+
+func TotalMatrices(matrices map[string][]map[string]interface{}) int {
+	total := 0
+	for _, matrixList := range matrices {
+		total += len(matrixList)
+	}
+	return total
+}
+
+func SortedWorkflows(matrices map[string][]map[string]interface{}) []string {
+	workflows := make([]string, 0, len(matrices))
+	for workflow, matrixList := range matrices {
+		if len(matrixList) == 0 {
+			continue
+		}
+		workflows = append(workflows, workflow)
+	}
+	sort.Strings(workflows)
+	return workflows
+}
+
+// Run dispatches the matrices of every workflow, up to the configured number
+// of dispatches at a time. Results are returned in workflow order regardless
+// of the order in which the dispatches complete.
+func Run(req Request) []Result {
+	results, jobs := plan(req)
+
+	concurrency := req.Config.GitHub.Dispatch.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	// Dry-run payload files are numbered in dispatch order.
+	if req.Client.Backend() == github.BackendDryRun {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+
+	for _, j := range jobs {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			results[j.index] = dispatchWithRetry(req, j)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+type job struct {
+	index  int
+	result Result
+	inputs map[string]string
+
+	// matrixHash and auditInputs describe the dispatch in the audit log,
+	// which does not store the payload itself.
+	matrixHash  string
+	auditInputs map[string]string
+}
+
+// plan builds a result for every dispatch. Dispatches that cannot be sent,
+// e.g. because their inputs cannot be built, already carry their error.
+func plan(req Request) ([]Result, []job) {
+	var results []Result
+	var jobs []job
+
+	for _, workflow := range SortedWorkflows(req.Matrices) {
+		matrices := req.Matrices[workflow]
+		result := Result{
+			Workflow:   workflow,
+			Repository: req.Config.GitHub.WorkflowRepository(workflow),
+			Branch:     req.Config.GitHub.WorkflowRef(workflow, req.Branch),
+			Matrices:   len(matrices),
+		}
+
+		wf, ok := req.Config.GitHub.Workflows[workflow]
+		if !ok {
+			result.Error = fmt.Errorf("workflow '%s' not found in configuration", workflow)
+			results = append(results, result)
+			continue
+		}
+
+		result.File = wf.File
+
+		chunks, err := Chunks(wf, matrices)
+		if err != nil {
+			result.Error = err
+			results = append(results, result)
+			continue
+		}
+
+		ctx := req.Placeholders
+		ctx.Branch = result.Branch
+		redactedCtx := ctx
+		redactedCtx.RedactEnv = true
+
+		// Chunks are consecutive, so the redacted matrices of a chunk are at
+		// the same positions. Without them, the matrices hold no secrets.
+		redactedMatrices := req.Redacted[workflow]
+		if len(redactedMatrices) != len(matrices) {
+			redactedMatrices = matrices
+		}
+		offset := 0
+
+		for i, chunk := range chunks {
+			redactedChunk := redactedMatrices[offset : offset+len(chunk)]
+			offset += len(chunk)
+
+			chunkResult := result
+			chunkResult.Matrices = len(chunk)
+			chunkResult.Chunk = i + 1
+			chunkResult.Chunks = len(chunks)
+
+			inputs, err := Inputs(req.Config, wf, chunk, req.ChangeLog, ctx)
+			if err != nil {
+				chunkResult.Error = err
+				results = append(results, chunkResult)
+				continue
+			}
+
+			redactedInputs, err := Inputs(req.Config, wf, redactedChunk, req.ChangeLog, redactedCtx)
+			if err != nil {
+				chunkResult.Error = err
+				results = append(results, chunkResult)
+				continue
+			}
+
+			auditInputs := make(map[string]string, len(redactedInputs))
+			for name, value := range redactedInputs {
+				if name != wf.Inputs.PayloadInput() {
+					auditInputs[name] = value
+				}
+			}
+
+			// Dry runs send nothing, so they write the redacted inputs.
+			if req.Client.Backend() == github.BackendDryRun {
+				inputs = redactedInputs
+			}
+
+			jobs = append(jobs, job{
+				index:       len(results),
+				result:      chunkResult,
+				inputs:      inputs,
+				matrixHash:  audit.MatrixHash(chunk),
+				auditInputs: auditInputs,
+			})
+			results = append(results, chunkResult)
+		}
+	}
+
+	return results, jobs
+}
+
+// dispatchWithRetry sends a dispatch, retrying rate limited and failed
+// requests with exponential backoff or the delay GitHub asked for.
+func dispatchWithRetry(req Request, j job) Result {
+	result := j.result
+
+	maxAttempts := req.Config.GitHub.Dispatch.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	backoff := initialBackoff
+	for {
+		result.Attempts++
+		result.DispatchedAt = time.Now()
+		result.Error = req.Client.DispatchWorkflow(result.Repository, result.File, result.Branch, j.inputs)
+
+		delay, retriable := github.RetryDelay(result.Error, time.Now())
+		if !retriable || result.Attempts >= maxAttempts {
+			recordAttempt(req, j, result, false)
+			return result
+		}
+
+		if delay == 0 {
+			delay = backoff
+			backoff = min(backoff*2, maxBackoff)
+		}
+		if delay > maxRetryDelay {
+			result.Error = fmt.Errorf("%w (retry not attempted: GitHub asked to wait %s)",
+				result.Error, delay.Round(time.Second))
+			recordAttempt(req, j, result, false)
+			return result
+		}
+
+		recordAttempt(req, j, result, true)
+		time.Sleep(delay)
+	}
+}
+
+// recordAttempt appends the attempt that produced result to the audit log.
+func recordAttempt(req Request, j job, result Result, retrying bool) {
+	record := audit.Record{
+		Repository:  result.Repository,
+		Workflow:    result.Workflow,
+		File:        result.File,
+		Ref:         result.Branch,
+		Part:        result.Part(),
+		Attempt:     result.Attempts,
+		MatrixHash:  j.matrixHash,
+		Inputs:      j.auditInputs,
+		InputValues: req.Placeholders.Inputs,
+		Outcome:     audit.OutcomeDispatched,
+	}
+
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+		record.Outcome = audit.OutcomeFailed
+		if retrying {
+			record.Outcome = audit.OutcomeRetried
+		}
+	}
+
+	req.Audit.Append(record)
+}
+
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, result := range results {
+		if result.Error != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func CombinedError(results []Result) error {
+	var errors []string
+	for _, result := range Failed(results) {
+		workflow := result.Workflow
+		if part := result.Part(); part != "" {
+			workflow += " " + part
+		}
+		errors = append(errors, fmt.Sprintf("'%s': %v", workflow, result.Error))
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("workflow trigger errors: %s", strings.Join(errors, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dispatch
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/github"
+)
+
+// Inputs builds the workflow_dispatch inputs of a workflow according to its
+// input mapping.
+func Inputs(
+	cfg *config.Config,
+	workflow config.WorkflowConfig,
+	matrices []map[string]interface{},
+	changeLog string,
+	ctx config.PlaceholderContext,
+) (map[string]string, error) {
+	inputs := make(map[string]string)
+
+	if name := workflow.Inputs.PayloadInput(); name != "" {
+		payload, err := github.Payload(matrices)
+		if err != nil {
+			return nil, err
+		}
+		inputs[name] = payload
+	}
+
+	if name := workflow.Inputs.ChangeLogInput(); name != "" {
+		inputs[name] = changeLog
+	}
+
+	for name, field := range workflow.Inputs.Fields {
+		value, err := fieldValue(matrices, field)
+		if err != nil {
+			return nil, fmt.Errorf("input %s: %w", name, err)
+		}
+		inputs[name] = value
+	}
+
+	for name, template := range workflow.Inputs.Templates {
+		inputs[name] = cfg.SubstituteVariables(template, ctx)
+	}
+
+	return inputs, nil
+}
+
+// fieldValue returns the value of a matrix field, which must be the same in
+// every matrix dispatched to the workflow.
+func fieldValue(matrices []map[string]interface{}, field string) (string, error) {
+	var value string
+
+	for i, matrix := range matrices {
+		raw, ok := matrix[field]
+		if !ok {
+			return "", fmt.Errorf("matrix field %s is missing from matrix #%d", field, i+1)
+		}
+
+		formatted, err := formatValue(raw)
+		if err != nil {
+			return "", err
+		}
+
+		if i > 0 && formatted != value {
+			return "", fmt.Errorf("matrix field %s differs between matrices (%s and %s)",
+				field, value, formatted)
+		}
+		value = formatted
+	}
+
+	return value, nil
+}
+
+func formatValue(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling matrix value: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dispatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+)
+
+// MaxPayloadBytes is GitHub's limit on the size of workflow_dispatch inputs.
+const MaxPayloadBytes = 65535
+
+// payloadOverhead is the length of {"matrices":[]} around the matrices.
+const payloadOverhead = len(`{"matrices":[]}`)
+
+// Chunks splits the matrices of a workflow into separate dispatches according
+// to its split settings. Matrices keep their order and are added to a chunk
+// until the next one would exceed a limit, so the same matrices always give
+// the same chunks. Payloads are never larger than GitHub accepts. Without
+// split settings all matrices are dispatched at once, and a payload that is
+// too large is an error.
+func Chunks(wf config.WorkflowConfig, matrices []map[string]interface{}) ([][]map[string]interface{}, error) {
+	maxMatrices := wf.Split.MaxMatrices
+	maxBytes := wf.Split.MaxPayloadBytes
+	split := maxBytes > 0 || maxMatrices > 0
+
+	if wf.Inputs.PayloadInput() == "" && !split {
+		return [][]map[string]interface{}{matrices}, nil
+	}
+
+	if maxBytes <= 0 || maxBytes > MaxPayloadBytes {
+		maxBytes = MaxPayloadBytes
+	}
+	limit := "max_payload_bytes"
+	if maxBytes != wf.Split.MaxPayloadBytes {
+		limit = "GitHub's input size limit"
+	}
+
+	var chunks [][]map[string]interface{}
+	var current []map[string]interface{}
+	size := payloadOverhead
+
+	for i, matrix := range matrices {
+		data, err := json.Marshal(matrix)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling matrix #%d: %w", i+1, err)
+		}
+
+		if payloadOverhead+len(data) > maxBytes {
+			return nil, fmt.Errorf("matrix #%d alone is %d bytes, more than %s of %d",
+				i+1, payloadOverhead+len(data), limit, maxBytes)
+		}
+
+		added := len(data)
+		if len(current) > 0 {
+			added++ // separating comma
+		}
+
+		full := (maxMatrices > 0 && len(current) == maxMatrices) || size+added > maxBytes
+		if len(current) > 0 && full {
+			if !split {
+				return nil, fmt.Errorf("payload of %d matrices is more than %s of %d bytes; "+
+					"configure split to dispatch them in several runs", len(matrices), limit, maxBytes)
+			}
+			chunks = append(chunks, current)
+			current = nil
+			size = payloadOverhead
+			added = len(data)
+		}
+
+		current = append(current, matrix)
+		size += added
+	}
+
+	if !split {
+		return [][]map[string]interface{}{matrices}, nil
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks, nil
+}
+
+// CheckChunks returns an error for the workflows whose matrices cannot be
+// dispatched within the payload limits, so that nothing is dispatched.
+func CheckChunks(cfg *config.Config, matrices map[string][]map[string]interface{}) error {
+	var errs []error
+	for _, workflow := range SortedWorkflows(matrices) {
+		wf, ok := cfg.GitHub.Workflows[workflow]
+		if !ok {
+			continue
+		}
+		if _, err := Chunks(wf, matrices[workflow]); err != nil {
+			errs = append(errs, fmt.Errorf("workflow '%s': %w", workflow, err))
+		}
+	}
+	return errors.Join(errs...)
+}
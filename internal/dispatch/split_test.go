@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dispatch
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+)
+
+// testMatrices returns n matrices such as {"id":"00"}, each 11 bytes of
+// JSON, padded with size bytes of data when size is positive.
+func testMatrices(n, size int) []map[string]interface{} {
+	matrices := make([]map[string]interface{}, n)
+	for i := range matrices {
+		matrices[i] = map[string]interface{}{"id": fmt.Sprintf("%02d", i)}
+		if size > 0 {
+			matrices[i]["data"] = strings.Repeat("x", size)
+		}
+	}
+	return matrices
+}
+
+// chunkIDs returns the ids of the matrices of every chunk.
+func chunkIDs(chunks [][]map[string]interface{}) [][]string {
+	ids := make([][]string, len(chunks))
+	for i, chunk := range chunks {
+		for _, matrix := range chunk {
+			ids[i] = append(ids[i], matrix["id"].(string))
+		}
+	}
+	return ids
+}
+
+func TestChunks(t *testing.T) {
+	noPayload := ""
+
+	// {"matrices":[]} is 15 bytes and every matrix adds 11, plus a comma
+	// after the first, so 50 bytes fit three matrices.
+	tests := []struct {
+		name     string
+		workflow config.WorkflowConfig
+		matrices []map[string]interface{}
+		want     [][]string
+		wantErr  string
+	}{
+		{
+			name:     "no split",
+			matrices: testMatrices(7, 0),
+			want:     [][]string{{"00", "01", "02", "03", "04", "05", "06"}},
+		},
+		{
+			name:     "max matrices",
+			workflow: config.WorkflowConfig{Split: config.SplitConfig{MaxMatrices: 3}},
+			matrices: testMatrices(7, 0),
+			want:     [][]string{{"00", "01", "02"}, {"03", "04", "05"}, {"06"}},
+		},
+		{
+			name:     "payload that fits exactly",
+			workflow: config.WorkflowConfig{Split: config.SplitConfig{MaxPayloadBytes: 50}},
+			matrices: testMatrices(7, 0),
+			want:     [][]string{{"00", "01", "02"}, {"03", "04", "05"}, {"06"}},
+		},
+		{
+			name:     "payload one byte short",
+			workflow: config.WorkflowConfig{Split: config.SplitConfig{MaxPayloadBytes: 49}},
+			matrices: testMatrices(7, 0),
+			want:     [][]string{{"00", "01"}, {"02", "03"}, {"04", "05"}, {"06"}},
+		},
+		{
+			name:     "lower limit wins",
+			workflow: config.WorkflowConfig{Split: config.SplitConfig{MaxPayloadBytes: 50, MaxMatrices: 2}},
+			matrices: testMatrices(5, 0),
+			want:     [][]string{{"00", "01"}, {"02", "03"}, {"04"}},
+		},
+		{
+			name:     "payload capped at GitHub's limit",
+			workflow: config.WorkflowConfig{Split: config.SplitConfig{MaxPayloadBytes: 200000}},
+			matrices: testMatrices(3, 30000),
+			want:     [][]string{{"00", "01"}, {"02"}},
+		},
+		{
+			name:     "no payload input",
+			workflow: config.WorkflowConfig{Inputs: config.WorkflowInputsConfig{Payload: &noPayload}},
+			matrices: testMatrices(3, 30000),
+			want:     [][]string{{"00", "01", "02"}},
+		},
+		{
+			name:     "matrix larger than the limit",
+			workflow: config.WorkflowConfig{Split: config.SplitConfig{MaxPayloadBytes: 20}},
+			matrices: testMatrices(2, 0),
+			wantErr:  "matrix #1 alone is 26 bytes, more than max_payload_bytes of 20",
+		},
+		{
+			name:     "matrix larger than GitHub's limit",
+			workflow: config.WorkflowConfig{Split: config.SplitConfig{MaxMatrices: 1}},
+			matrices: append(testMatrices(1, 0), testMatrices(1, 70000)...),
+			wantErr:  "matrix #2 alone is 70036 bytes, more than GitHub's input size limit of 65535",
+		},
+		{
+			name:     "payload too large without split",
+			matrices: testMatrices(3, 30000),
+			wantErr: "payload of 3 matrices is more than GitHub's input size limit of 65535 bytes; " +
+				"configure split to dispatch them in several runs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, err := Chunks(tt.workflow, tt.matrices)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := chunkIDs(chunks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunksAreStable(t *testing.T) {
+	workflows := []config.WorkflowConfig{
+		{Split: config.SplitConfig{MaxMatrices: 4}},
+		{Split: config.SplitConfig{MaxPayloadBytes: 100}},
+	}
+
+	for _, wf := range workflows {
+		t.Run(fmt.Sprintf("%+v", wf.Split), func(t *testing.T) {
+			first, err := Chunks(wf, testMatrices(10, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := Chunks(wf, testMatrices(10, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(chunkIDs(first), chunkIDs(second)) {
+				t.Errorf("chunks differ between runs: %v and %v", chunkIDs(first), chunkIDs(second))
+			}
+
+			// Matrices added at the end do not move earlier boundaries.
+			more, err := Chunks(wf, testMatrices(20, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			full := chunkIDs(first)[:len(first)-1]
+			if got := chunkIDs(more)[:len(full)]; !reflect.DeepEqual(got, full) {
+				t.Errorf("chunks = %v, want them to start with %v", got, full)
+			}
+		})
+	}
+}
+
+func TestCheckChunks(t *testing.T) {
+	cfg := &config.Config{GitHub: config.GitHubConfig{Workflows: map[string]config.WorkflowConfig{
+		"small": {File: "small.yml"},
+		"large": {File: "large.yml"},
+		"split": {File: "split.yml", Split: config.SplitConfig{MaxMatrices: 1}},
+	}}}
+
+	matrices := map[string][]map[string]interface{}{
+		"small":   testMatrices(2, 0),
+		"large":   testMatrices(3, 30000),
+		"split":   testMatrices(3, 30000),
+		"unknown": testMatrices(3, 30000),
+	}
+
+	err := CheckChunks(cfg, matrices)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := "workflow 'large': payload of 3 matrices is more than GitHub's input size limit of 65535 bytes; " +
+		"configure split to dispatch them in several runs"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+
+	delete(matrices, "large")
+	if err := CheckChunks(cfg, matrices); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -19,56 +19,62 @@ package extractor
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 
-	"github.com/PraveenGongada/catalyst/internal/config"
-	"github.com/PraveenGongada/catalyst/internal/constants"
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
+	"github.com/PraveenGongada/catalyst/internal/matrix"
 )
 
 type OutputFormat struct {
 	Matrices []map[string]interface{} `json:"matrices" yaml:"matrices"`
 }
 
-var inputPlaceholderPattern = regexp.MustCompile(constants.RegexInputPlaceholder)
-
-func ExtractWorkflowMatrices(
-	cfg *config.Config,
-	workflowKey string,
-) ([]map[string]interface{}, error) {
-	var matrices []map[string]interface{}
+// Dispatch is the body of a workflow dispatch request: the ref the
+// workflow runs on and its workflow_dispatch inputs.
+type Dispatch struct {
+	Ref    string            `json:"ref"`
+	Inputs map[string]string `json:"inputs"`
+}
 
-	for _, appConfig := range cfg.Matrix {
-		for _, platformConfig := range appConfig {
-			for _, envConfig := range platformConfig {
-				if envConfig.Workflow == workflowKey {
-					filteredMatrix := filterInputPlaceholders(envConfig.Matrix)
+// ExtractWorkflowMatrices returns the matrices the generator's selections
+// dispatch to the workflow, generated exactly as for a dispatch.
+func ExtractWorkflowMatrices(generator *matrix.Generator, workflowKey string) []map[string]interface{} {
+	return generator.GroupedMatricesPurified()[workflowKey]
+}
 
-					matrices = append(matrices, filteredMatrix)
-				}
-			}
-		}
+// ExtractDispatches returns the requests the generator's selections would
+// send to dispatch the workflow, one per payload if the matrices are split.
+// The inputs are built exactly as for a dispatch, including renamed,
+// field and template inputs.
+func ExtractDispatches(generator *matrix.Generator, workflowKey, changeLog string) ([]Dispatch, error) {
+	cfg := generator.Config
+	wf := cfg.GitHub.Workflows[workflowKey]
+
+	matrices := ExtractWorkflowMatrices(generator, workflowKey)
+	if len(matrices) == 0 {
+		return nil, nil
 	}
 
-	return matrices, nil
-}
+	chunks, err := dispatch.Chunks(wf, matrices)
+	if err != nil {
+		return nil, err
+	}
 
-func filterInputPlaceholders(matrix map[string]interface{}) map[string]interface{} {
-	filtered := make(map[string]interface{})
+	ctx := generator.PlaceholderContext()
+	ctx.Branch = cfg.GitHub.WorkflowRef(workflowKey, generator.Branch)
 
-	for key, value := range matrix {
-		if strValue, ok := value.(string); ok {
-			if inputPlaceholderPattern.MatchString(strValue) {
-				continue
-			}
+	var dispatches []Dispatch
+	for _, chunk := range chunks {
+		inputs, err := dispatch.Inputs(cfg, wf, chunk, changeLog, ctx)
+		if err != nil {
+			return nil, err
 		}
-
-		filtered[key] = value
+		dispatches = append(dispatches, Dispatch{Ref: ctx.Branch, Inputs: inputs})
 	}
 
-	return filtered
+	return dispatches, nil
 }
 
 func FormatOutput(matrices []map[string]interface{}, format string) (string, error) {
@@ -95,3 +101,19 @@ func FormatOutput(matrices []map[string]interface{}, format string) (string, err
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
 }
+
+// FormatDispatches renders each dispatch request body as a line of JSON.
+func FormatDispatches(dispatches []Dispatch) (string, error) {
+	var output strings.Builder
+
+	for _, d := range dispatches {
+		line, err := json.Marshal(d)
+		if err != nil {
+			return "", fmt.Errorf("error marshaling to JSON: %w", err)
+		}
+		output.Write(line)
+		output.WriteString("\n")
+	}
+
+	return output.String(), nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func IsInstalled() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is not installed or not in your PATH")
+	}
+	return nil
+}
+
+func Run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w, output: %s",
+			strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func Tags(dir string, pattern string) ([]string, error) {
+	args := []string{"tag", "--list"}
+	if pattern != "" {
+		args = append(args, pattern)
+	}
+
+	output, err := Run(dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitLines(output), nil
+}
+
+// RemoteRefs returns the names of the remote-tracking branches, without
+// their remote, and of the tags known to the local repository.
+func RemoteRefs(dir string) ([]string, error) {
+	output, err := Run(dir, "for-each-ref", "--format=%(refname)", "refs/remotes", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var refs []string
+	for _, ref := range splitLines(output) {
+		if name, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+			ref = name
+		} else {
+			// refs/remotes/<remote>/<branch>
+			parts := strings.SplitN(ref, "/", 4)
+			if len(parts) < 4 || parts[3] == "HEAD" {
+				continue
+			}
+			ref = parts[3]
+		}
+
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs, nil
+}
+
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+type Commit struct {
+	Hash    string
+	Subject string
+}
+
+func ResolveRef(dir string, ref string) (string, error) {
+	for _, candidate := range []string{ref, "origin/" + ref} {
+		if _, err := Run(dir, "rev-parse", "--verify", "--quiet", candidate+"^{commit}"); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("ref '%s' not found in local repository", ref)
+}
+
+func LatestTag(dir string, target string, pattern string) (string, error) {
+	args := []string{"describe", "--tags", "--abbrev=0"}
+	if pattern != "" {
+		args = append(args, "--match", pattern)
+	}
+	args = append(args, target)
+
+	tag, err := Run(dir, args...)
+	if err != nil {
+		return "", fmt.Errorf("no tag found before %s: %w", target, err)
+	}
+	return tag, nil
+}
+
+func Log(dir string, from string, to string, paths []string) ([]Commit, error) {
+	args := []string{"log", "--no-merges", "--format=%H%x1f%s", from + ".." + to}
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
+
+	output, err := Run(dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, line := range splitLines(output) {
+		hash, subject, found := strings.Cut(line, "\x1f")
+		if !found {
+			continue
+		}
+		commits = append(commits, Commit{Hash: hash, Subject: subject})
+	}
+
+	return commits, nil
+}
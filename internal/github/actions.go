@@ -19,50 +19,84 @@ package github
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
+	"os"
+	"strings"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
 )
 
-func IsGHInstalled() error {
-	_, err := exec.LookPath("gh")
-	if err != nil {
-		return fmt.Errorf("GitHub CLI (gh) is not installed or not in your PATH. " +
-			"Please install it from https://cli.github.com/manual/installation " +
-			"and run 'gh auth login' before using Catalyst")
-	}
-	return nil
+const (
+	BackendAuto = "auto"
+	BackendREST = "rest"
+	BackendGH   = "gh"
+)
+
+type Client interface {
+	DispatchWorkflow(
+		repository string,
+		workflowID string,
+		ref string,
+		inputs map[string]string,
+	) error
+	GetJSON(path string, v interface{}) error
+	Backend() string
 }
 
-func TriggerWorkflow(
-	repository string,
-	workflowID string,
-	matrices []map[string]interface{},
-	changeLog string,
-	branchName string,
-) error {
-	payloadBytes, err := json.Marshal(map[string]interface{}{
-		"matrices": matrices,
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling payload: %w", err)
+func NewClient(cfg config.GitHubConfig) (Client, error) {
+	backend := strings.ToLower(strings.TrimSpace(cfg.Backend))
+	if backend == "" {
+		backend = BackendAuto
 	}
 
-	args := []string{
-		"workflow", "run",
-		workflowID,
-		"--repo", repository,
-		"--ref", branchName,
-	}
+	switch backend {
+	case BackendREST:
+		token, err := ResolveToken(APIBaseURL(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return NewRESTClient(APIBaseURL(cfg), token), nil
 
-	// Add inputs as raw fields
-	args = append(args, "--raw-field", fmt.Sprintf("payload=%s", string(payloadBytes)))
-	args = append(args, "--raw-field", fmt.Sprintf("change_log=%s", changeLog))
+	case BackendGH:
+		if err := IsGHInstalled(); err != nil {
+			return nil, err
+		}
+		return NewGHClient(), nil
 
-	cmd := exec.Command("gh", args...)
+	case BackendAuto:
+		if token, err := ResolveToken(APIBaseURL(cfg)); err == nil {
+			return NewRESTClient(APIBaseURL(cfg), token), nil
+		}
+		if err := IsGHInstalled(); err == nil {
+			return NewGHClient(), nil
+		}
+		return nil, fmt.Errorf("no GitHub credentials found. Set GITHUB_TOKEN or GH_TOKEN, " +
+			"or install the GitHub CLI from https://cli.github.com/manual/installation " +
+			"and run 'gh auth login' before using Catalyst")
+
+	default:
+		return nil, fmt.Errorf("unknown GitHub backend '%s'", cfg.Backend)
+	}
+}
 
-	output, err := cmd.CombinedOutput()
+func APIBaseURL(cfg config.GitHubConfig) string {
+	if cfg.APIURL != "" {
+		return strings.TrimRight(cfg.APIURL, "/")
+	}
+	if envURL := os.Getenv("GITHUB_API_URL"); envURL != "" {
+		return strings.TrimRight(envURL, "/")
+	}
+	return DefaultAPIURL
+}
+
+// Payload marshals the matrices of a workflow into the JSON document sent
+// as its payload input.
+func Payload(matrices []map[string]interface{}) (string, error) {
+	payloadBytes, err := json.Marshal(map[string]interface{}{
+		"matrices": matrices,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to trigger GitHub workflow: %w, output: %s", err, string(output))
+		return "", fmt.Errorf("error marshaling payload: %w", err)
 	}
 
-	return nil
+	return string(payloadBytes), nil
 }
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ghHost struct {
+	OAuthToken string `yaml:"oauth_token"`
+}
+
+func ResolveToken(apiBaseURL string) (string, error) {
+	for _, envVar := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := strings.TrimSpace(os.Getenv(envVar)); token != "" {
+			return token, nil
+		}
+	}
+
+	hostsPath := ghHostsPath()
+	if hostsPath == "" {
+		return "", fmt.Errorf("no GitHub token found in GITHUB_TOKEN or GH_TOKEN")
+	}
+
+	data, err := os.ReadFile(hostsPath)
+	if err != nil {
+		return "", fmt.Errorf("no GitHub token found in GITHUB_TOKEN, GH_TOKEN or %s", hostsPath)
+	}
+
+	var hosts map[string]ghHost
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return "", fmt.Errorf("failed to parse gh hosts file %s: %w", hostsPath, err)
+	}
+
+	host := apiHost(apiBaseURL)
+	if entry, ok := hosts[host]; ok && entry.OAuthToken != "" {
+		return entry.OAuthToken, nil
+	}
+
+	return "", fmt.Errorf("no GitHub token for %s found in %s", host, hostsPath)
+}
+
+func ghHostsPath() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI", "hosts.yml")
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh", "hosts.yml")
+}
+
+func apiHost(apiBaseURL string) string {
+	parsed, err := url.Parse(apiBaseURL)
+	if err != nil || parsed.Host == "" {
+		return "github.com"
+	}
+
+	host := parsed.Hostname()
+	if host == "api.github.com" {
+		return "github.com"
+	}
+	return strings.TrimPrefix(host, "api.")
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	BackendDryRun = "dry-run"
+	StdoutOutput  = "-"
+)
+
+type DryRunPayload struct {
+	Repository   string            `json:"repository"`
+	WorkflowFile string            `json:"workflowFile"`
+	Branch       string            `json:"branch"`
+	Inputs       map[string]string `json:"inputs"`
+	Command      string            `json:"command"`
+}
+
+type DryRunClient struct {
+	OutputDir string
+	Stdout    io.Writer
+
+	mu    sync.Mutex
+	files map[string]int
+}
+
+func NewDryRunClient(outputDir string) *DryRunClient {
+	if outputDir == "" {
+		outputDir = DefaultDryRunDir()
+	}
+
+	return &DryRunClient{
+		OutputDir: outputDir,
+		Stdout:    os.Stdout,
+		files:     make(map[string]int),
+	}
+}
+
+func DefaultDryRunDir() string {
+	return fmt.Sprintf("catalyst-payloads-%s", time.Now().Format("20060102-150405"))
+}
+
+func (c *DryRunClient) Backend() string {
+	return BackendDryRun
+}
+
+func (c *DryRunClient) GetJSON(_ string, _ interface{}) error {
+	return fmt.Errorf("GitHub API is not available in dry-run mode")
+}
+
+func (c *DryRunClient) DispatchWorkflow(
+	repository string,
+	workflowID string,
+	ref string,
+	inputs map[string]string,
+) error {
+	payloadBytes, err := json.MarshalIndent(DryRunPayload{
+		Repository:   repository,
+		WorkflowFile: workflowID,
+		Branch:       ref,
+		Inputs:       inputs,
+		Command:      GHCommandLine(repository, workflowID, ref, inputs),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling payload: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.OutputDir == StdoutOutput {
+		_, err := fmt.Fprintln(c.Stdout, string(payloadBytes))
+		return err
+	}
+
+	if err := os.MkdirAll(c.OutputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	baseName := fmt.Sprintf("%s-%s",
+		strings.TrimSuffix(workflowID, filepath.Ext(workflowID)),
+		strings.ReplaceAll(repository, "/", "-"),
+	)
+	c.files[baseName]++
+	if c.files[baseName] > 1 {
+		baseName = fmt.Sprintf("%s-%d", baseName, c.files[baseName])
+	}
+
+	filename := filepath.Join(c.OutputDir, baseName+".json")
+	if err := os.WriteFile(filename, payloadBytes, 0o644); err != nil {
+		return fmt.Errorf("failed to write payload file: %w", err)
+	}
+
+	return nil
+}
+
+func GHCommandLine(repository, workflowID, ref string, inputs map[string]string) string {
+	args := []string{
+		"gh", "workflow", "run",
+		shellQuote(workflowID),
+		"--repo", shellQuote(repository),
+		"--ref", shellQuote(ref),
+	}
+
+	keys := make([]string, 0, len(inputs))
+	for key := range inputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, "--raw-field", shellQuote(fmt.Sprintf("%s=%s", key, inputs[key])))
+	}
+
+	return strings.Join(args, " ")
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			strings.ContainsRune("-_./:=@,+", r))
+	}) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+)
+
+func IsGHInstalled() error {
+	_, err := exec.LookPath("gh")
+	if err != nil {
+		return fmt.Errorf("GitHub CLI (gh) is not installed or not in your PATH. " +
+			"Please install it from https://cli.github.com/manual/installation " +
+			"and run 'gh auth login' before using Catalyst")
+	}
+	return nil
+}
+
+type GHClient struct{}
+
+func NewGHClient() *GHClient {
+	return &GHClient{}
+}
+
+func (c *GHClient) Backend() string {
+	return BackendGH
+}
+
+func (c *GHClient) DispatchWorkflow(
+	repository string,
+	workflowID string,
+	ref string,
+	inputs map[string]string,
+) error {
+	args := []string{
+		"workflow", "run",
+		workflowID,
+		"--repo", repository,
+		"--ref", ref,
+	}
+
+	// Add inputs as raw fields
+	keys := make([]string, 0, len(inputs))
+	for key := range inputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, "--raw-field", fmt.Sprintf("%s=%s", key, inputs[key]))
+	}
+
+	cmd := exec.Command("gh", args...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to trigger GitHub workflow: %w, output: %s", err, string(output))
+	}
+
+	return nil
+}
+
+func (c *GHClient) GetJSON(path string, v interface{}) error {
+	cmd := exec.Command("gh", "api", "-H", "Accept: application/vnd.github+json", path)
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("gh api %s failed: %w, output: %s", path, err, string(exitErr.Stderr))
+		}
+		return fmt.Errorf("gh api %s failed: %w", path, err)
+	}
+
+	if err := json.Unmarshal(output, v); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return nil
+}
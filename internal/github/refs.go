@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package github
+
+import "fmt"
+
+const (
+	refsPerPage = 100
+
+	// maxRefPages bounds the number of requests made to list the branches
+	// or tags of very large repositories, whose lists are then truncated.
+	maxRefPages = 10
+)
+
+// ListBranches returns the names of the repository's branches. It reports
+// true if the repository has more branches than were listed.
+func ListBranches(client Client, repository string) ([]string, bool, error) {
+	names, truncated, err := listRefNames(client, fmt.Sprintf("/repos/%s/branches", repository))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list branches: %w", err)
+	}
+	return names, truncated, nil
+}
+
+// ListTags returns the names of the repository's tags. It reports true if
+// the repository has more tags than were listed.
+func ListTags(client Client, repository string) ([]string, bool, error) {
+	names, truncated, err := listRefNames(client, fmt.Sprintf("/repos/%s/tags", repository))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return names, truncated, nil
+}
+
+// listRefNames pages through the refs until a page is not full, which is
+// the last one.
+func listRefNames(client Client, path string) ([]string, bool, error) {
+	var names []string
+
+	for page := 1; page <= maxRefPages; page++ {
+		var refs []struct {
+			Name string `json:"name"`
+		}
+		if err := client.GetJSON(fmt.Sprintf("%s?per_page=%d&page=%d", path, refsPerPage, page), &refs); err != nil {
+			return nil, false, err
+		}
+
+		for _, ref := range refs {
+			names = append(names, ref.Name)
+		}
+		if len(refs) < refsPerPage {
+			return names, false, nil
+		}
+	}
+
+	return names, true, nil
+}
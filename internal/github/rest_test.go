@@ -0,0 +1,351 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRESTClientHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		dispatch    bool
+		wantAuth    string
+		wantContent string
+	}{
+		{name: "get with token", token: "secret", wantAuth: "Bearer secret"},
+		{name: "get without token"},
+		{
+			name:        "dispatch with token",
+			token:       "secret",
+			dispatch:    true,
+			wantAuth:    "Bearer secret",
+			wantContent: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(w, `{}`)
+			}))
+			defer server.Close()
+
+			client := NewRESTClient(server.URL+"/", tt.token)
+
+			var err error
+			if tt.dispatch {
+				err = client.DispatchWorkflow("org/repo", "deploy.yml", "main", map[string]string{"payload": "{}"})
+			} else {
+				err = client.GetJSON("/repos/org/repo", &struct{}{})
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if accept := got.Header.Get("Accept"); accept != "application/vnd.github+json" {
+				t.Errorf("Accept = %q", accept)
+			}
+			if version := got.Header.Get("X-GitHub-Api-Version"); version != apiVersion {
+				t.Errorf("X-GitHub-Api-Version = %q, want %q", version, apiVersion)
+			}
+			if auth := got.Header.Get("Authorization"); auth != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", auth, tt.wantAuth)
+			}
+			if content := got.Header.Get("Content-Type"); content != tt.wantContent {
+				t.Errorf("Content-Type = %q, want %q", content, tt.wantContent)
+			}
+
+			if tt.dispatch {
+				if got.URL.Path != "/repos/org/repo/actions/workflows/deploy.yml/dispatches" {
+					t.Errorf("path = %q", got.URL.Path)
+				}
+
+				var request struct {
+					Ref    string            `json:"ref"`
+					Inputs map[string]string `json:"inputs"`
+				}
+				if err := json.Unmarshal(body, &request); err != nil {
+					t.Fatalf("invalid dispatch body %q: %v", body, err)
+				}
+				if request.Ref != "main" || request.Inputs["payload"] != "{}" {
+					t.Errorf("dispatch body = %s", body)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatchWorkflowStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantErr     bool
+		wantMessage string
+	}{
+		{name: "no content", status: http.StatusNoContent},
+		{name: "ok", status: http.StatusOK},
+		{
+			name:        "json message",
+			status:      http.StatusUnprocessableEntity,
+			body:        `{"message":"Unexpected inputs provided: [\"x\"]"}`,
+			wantErr:     true,
+			wantMessage: `Unexpected inputs provided: ["x"]`,
+		},
+		{
+			name:        "plain text",
+			status:      http.StatusBadGateway,
+			body:        "  bad gateway\n",
+			wantErr:     true,
+			wantMessage: "bad gateway",
+		},
+		{name: "empty body", status: http.StatusNotFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			err := NewRESTClient(server.URL, "token").DispatchWorkflow("org/repo", "deploy.yml", "main", nil)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error %v is not an APIError", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		status        int
+		header        map[string]string
+		body          string
+		wantDelay     time.Duration
+		wantRetriable bool
+	}{
+		{
+			name:          "too many requests with retry-after seconds",
+			status:        http.StatusTooManyRequests,
+			header:        map[string]string{"Retry-After": "7"},
+			wantDelay:     7 * time.Second,
+			wantRetriable: true,
+		},
+		{
+			name:          "retry-after date",
+			status:        http.StatusServiceUnavailable,
+			header:        map[string]string{"Retry-After": now.Add(90 * time.Second).UTC().Format(http.TimeFormat)},
+			wantDelay:     90 * time.Second,
+			wantRetriable: true,
+		},
+		{
+			name:   "forbidden with exhausted rate limit",
+			status: http.StatusForbidden,
+			header: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(now.Add(time.Minute).Unix(), 10),
+			},
+			wantDelay:     time.Minute,
+			wantRetriable: true,
+		},
+		{
+			name:          "forbidden with retry-after",
+			status:        http.StatusForbidden,
+			header:        map[string]string{"Retry-After": "3"},
+			wantDelay:     3 * time.Second,
+			wantRetriable: true,
+		},
+		{
+			name:          "secondary rate limit without headers",
+			status:        http.StatusForbidden,
+			body:          `{"message":"You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`,
+			wantDelay:     time.Minute,
+			wantRetriable: true,
+		},
+		{
+			name:          "secondary rate limit with retry-after",
+			status:        http.StatusForbidden,
+			header:        map[string]string{"Retry-After": "5"},
+			body:          `{"message":"You have exceeded a secondary rate limit."}`,
+			wantDelay:     5 * time.Second,
+			wantRetriable: true,
+		},
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "forbidden by permissions", status: http.StatusForbidden, body: `{"message":"Resource not accessible by integration"}`},
+		{name: "bad gateway without hint", status: http.StatusBadGateway, wantRetriable: true},
+		{name: "not found", status: http.StatusNotFound, header: map[string]string{"Retry-After": "5"}},
+		{name: "unprocessable", status: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.header {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			err := NewRESTClient(server.URL, "token").DispatchWorkflow("org/repo", "deploy.yml", "main", nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			delay, retriable := RetryDelay(err, now)
+			if retriable != tt.wantRetriable || delay != tt.wantDelay {
+				t.Errorf("RetryDelay = %s, %t; want %s, %t", delay, retriable, tt.wantDelay, tt.wantRetriable)
+			}
+		})
+	}
+}
+
+func TestRetryDelayOtherErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	tests := []struct {
+		name          string
+		err           error
+		wantDelay     time.Duration
+		wantRetriable bool
+	}{
+		{name: "nil", err: nil},
+		{
+			name:          "connection refused",
+			err:           NewRESTClient(server.URL, "token").DispatchWorkflow("org/repo", "deploy.yml", "main", nil),
+			wantRetriable: true,
+		},
+		{name: "gh rate limit", err: errors.New("HTTP 403: API rate limit exceeded"), wantRetriable: true},
+		{
+			name:          "gh secondary rate limit",
+			err:           errors.New("HTTP 403: You have exceeded a secondary rate limit."),
+			wantDelay:     time.Minute,
+			wantRetriable: true,
+		},
+		{name: "gh server error", err: errors.New("HTTP 502: Bad Gateway"), wantRetriable: true},
+		{name: "gh not found", err: errors.New("HTTP 404: Not Found")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retriable := RetryDelay(tt.err, time.Now())
+			if retriable != tt.wantRetriable || delay != tt.wantDelay {
+				t.Errorf("RetryDelay(%v) = %s, %t; want %s, %t", tt.err, delay, retriable, tt.wantDelay, tt.wantRetriable)
+			}
+		})
+	}
+}
+
+func TestListBranchesPagination(t *testing.T) {
+	tests := []struct {
+		name          string
+		branches      int
+		wantNames     int
+		wantRequests  int
+		wantTruncated bool
+	}{
+		{name: "none", branches: 0, wantNames: 0, wantRequests: 1},
+		{name: "one page", branches: 42, wantNames: 42, wantRequests: 1},
+		{name: "full page", branches: refsPerPage, wantNames: refsPerPage, wantRequests: 2},
+		{name: "several pages", branches: 250, wantNames: 250, wantRequests: 3},
+		{
+			name:          "more than listed",
+			branches:      maxRefPages*refsPerPage + 1,
+			wantNames:     maxRefPages * refsPerPage,
+			wantRequests:  maxRefPages,
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/repos/org/repo/branches" {
+					t.Errorf("path = %q", r.URL.Path)
+				}
+
+				perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				if perPage != refsPerPage || page != requests {
+					t.Errorf("request %d asked for per_page=%d&page=%d", requests, perPage, page)
+				}
+
+				branches := []map[string]string{}
+				for i := (page - 1) * perPage; i < min(page*perPage, tt.branches); i++ {
+					branches = append(branches, map[string]string{"name": fmt.Sprintf("branch-%d", i)})
+				}
+				json.NewEncoder(w).Encode(branches)
+			}))
+			defer server.Close()
+
+			names, truncated, err := ListBranches(NewRESTClient(server.URL, "token"), "org/repo")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(names) != tt.wantNames {
+				t.Errorf("got %d names, want %d", len(names), tt.wantNames)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", requests, tt.wantRequests)
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("truncated = %t, want %t", truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package github
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ghRetryPattern matches the HTTP errors gh prints for responses worth retrying.
+var ghRetryPattern = regexp.MustCompile(`HTTP (429|500|502|503|504)|(?i)rate limit`)
+
+// secondaryRateLimitPattern matches the message of GitHub's secondary rate
+// limit responses, which may come without any header saying how long to wait.
+var secondaryRateLimitPattern = regexp.MustCompile(`(?i)secondary rate limit`)
+
+// secondaryRateLimitDelay is how long to wait after hitting a secondary rate
+// limit without a Retry-After header, as GitHub recommends.
+const secondaryRateLimitDelay = time.Minute
+
+// RetryDelay reports whether a failed request may succeed when retried, and
+// how long GitHub asked to wait first. A zero delay means GitHub gave no hint
+// and the caller should back off on its own.
+func RetryDelay(err error, now time.Time) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.retryDelay(now)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return 0, true
+	}
+
+	if secondaryRateLimitPattern.MatchString(err.Error()) {
+		return secondaryRateLimitDelay, true
+	}
+	if ghRetryPattern.MatchString(err.Error()) {
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func (e *APIError) retryDelay(now time.Time) (time.Duration, bool) {
+	rateLimited := e.Header.Get("X-RateLimit-Remaining") == "0"
+	secondary := secondaryRateLimitPattern.MatchString(e.Message)
+
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+	case e.StatusCode == http.StatusForbidden && (rateLimited || secondary || e.Header.Get("Retry-After") != ""):
+	case e.StatusCode == http.StatusInternalServerError,
+		e.StatusCode == http.StatusBadGateway,
+		e.StatusCode == http.StatusServiceUnavailable,
+		e.StatusCode == http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if retryAfter := e.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(retryAfter); err == nil && at.After(now) {
+			return at.Sub(now), true
+		}
+	}
+
+	if rateLimited {
+		if reset, err := strconv.ParseInt(e.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if at := time.Unix(reset, 0); at.After(now) {
+				return at.Sub(now), true
+			}
+		}
+	}
+
+	if secondary {
+		return secondaryRateLimitDelay, true
+	}
+	return 0, true
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+const (
+	RunStatusCompleted = "completed"
+
+	// Runs created slightly before the local dispatch time are still ours when
+	// the local clock is ahead of GitHub's.
+	dispatchClockSkew = 30 * time.Second
+)
+
+type Actor struct {
+	Login string `json:"login"`
+}
+
+type WorkflowRun struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	HTMLURL      string    `json:"html_url"`
+	HeadBranch   string    `json:"head_branch"`
+	Event        string    `json:"event"`
+	Actor        Actor     `json:"actor"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	RunStartedAt time.Time `json:"run_started_at"`
+}
+
+type Job struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+func (r WorkflowRun) IsCompleted() bool {
+	return r.Status == RunStatusCompleted
+}
+
+func (r WorkflowRun) Elapsed(now time.Time) time.Duration {
+	start := r.RunStartedAt
+	if start.IsZero() {
+		start = r.CreatedAt
+	}
+	if start.IsZero() {
+		return 0
+	}
+
+	end := now
+	if r.IsCompleted() && !r.UpdatedAt.IsZero() {
+		end = r.UpdatedAt
+	}
+	return end.Sub(start)
+}
+
+func CurrentUser(client Client) (string, error) {
+	var user Actor
+	if err := client.GetJSON("/user", &user); err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+	return user.Login, nil
+}
+
+func FindWorkflowRuns(
+	client Client,
+	repository string,
+	workflowID string,
+	branch string,
+	actor string,
+	dispatchedAt time.Time,
+) ([]WorkflowRun, error) {
+	since := dispatchedAt.Add(-dispatchClockSkew).UTC()
+
+	query := url.Values{}
+	query.Set("event", "workflow_dispatch")
+	query.Set("created", ">="+since.Format(time.RFC3339))
+	if branch != "" {
+		query.Set("branch", branch)
+	}
+	if actor != "" {
+		query.Set("actor", actor)
+	}
+
+	path := fmt.Sprintf("/repos/%s/actions/workflows/%s/runs?%s",
+		repository, url.PathEscape(workflowID), query.Encode())
+
+	var response struct {
+		WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+	}
+	if err := client.GetJSON(path, &response); err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+
+	runs := make([]WorkflowRun, 0, len(response.WorkflowRuns))
+	for _, run := range response.WorkflowRuns {
+		if run.CreatedAt.Before(since) {
+			continue
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].CreatedAt.Before(runs[j].CreatedAt)
+	})
+
+	return runs, nil
+}
+
+func GetWorkflowRun(client Client, repository string, runID int64) (WorkflowRun, error) {
+	var run WorkflowRun
+	path := fmt.Sprintf("/repos/%s/actions/runs/%d", repository, runID)
+	if err := client.GetJSON(path, &run); err != nil {
+		return run, fmt.Errorf("failed to get workflow run %d: %w", runID, err)
+	}
+	return run, nil
+}
+
+func ListRunJobs(client Client, repository string, runID int64) ([]Job, error) {
+	var response struct {
+		Jobs []Job `json:"jobs"`
+	}
+	path := fmt.Sprintf("/repos/%s/actions/runs/%d/jobs?per_page=100", repository, runID)
+	if err := client.GetJSON(path, &response); err != nil {
+		return nil, fmt.Errorf("failed to list jobs for run %d: %w", runID, err)
+	}
+	return response.Jobs, nil
+}
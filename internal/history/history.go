@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package history
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
+)
+
+const LatestID = "last"
+
+type Workflow struct {
+	Key        string  `json:"key"`
+	File       string  `json:"file"`
+	Repository string  `json:"repository,omitempty"`
+	Ref        string  `json:"ref,omitempty"`
+	RunIDs     []int64 `json:"run_ids,omitempty"`
+}
+
+type Entry struct {
+	ID           string                              `json:"id"`
+	Timestamp    time.Time                           `json:"timestamp"`
+	Repository   string                              `json:"repository"`
+	Apps         []string                            `json:"apps"`
+	Platforms    []string                            `json:"platforms"`
+	Environments []string                            `json:"environments"`
+	Inputs       map[string]string                   `json:"inputs"`
+	Branch       string                              `json:"branch"`
+	ChangeLog    string                              `json:"change_log"`
+	Matrices     map[string][]map[string]interface{} `json:"matrices"`
+	Workflows    []Workflow                          `json:"workflows"`
+}
+
+// Store holds the deployments of a repository. The deployments of all
+// repositories share a directory, so List and Get skip the entries of other
+// repositories.
+type Store struct {
+	Dir        string
+	Repository string
+}
+
+func DefaultStore(repository string) (*Store, error) {
+	if dir := os.Getenv("CATALYST_HISTORY_DIR"); dir != "" {
+		return &Store{Dir: dir, Repository: repository}, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+
+	return &Store{Dir: filepath.Join(configDir, "catalyst", "history"), Repository: repository}, nil
+}
+
+// NewEntry records a deployment of the configuration of repository, i.e.
+// its github.repository, whichever repositories its workflows ran in.
+func NewEntry(
+	repository string,
+	apps []string,
+	platforms []string,
+	environments []string,
+	inputs map[string]string,
+	branch string,
+	changeLog string,
+	matrices map[string][]map[string]interface{},
+	results []dispatch.Result,
+) *Entry {
+	entry := &Entry{
+		Timestamp:    time.Now(),
+		Repository:   repository,
+		Apps:         apps,
+		Platforms:    platforms,
+		Environments: environments,
+		Inputs:       inputs,
+		Branch:       branch,
+		ChangeLog:    changeLog,
+		Matrices:     make(map[string][]map[string]interface{}),
+	}
+
+	recorded := make(map[string]bool)
+	for _, result := range results {
+		if result.Error != nil || recorded[result.Workflow] {
+			continue
+		}
+		recorded[result.Workflow] = true
+
+		entry.Workflows = append(entry.Workflows, Workflow{
+			Key:        result.Workflow,
+			File:       result.File,
+			Repository: result.Repository,
+			Ref:        result.Branch,
+		})
+		entry.Matrices[result.Workflow] = matrices[result.Workflow]
+	}
+
+	return entry
+}
+
+func (s *Store) Save(entry *Entry) error {
+	if entry.ID == "" {
+		id, err := newID(entry.Timestamp)
+		if err != nil {
+			return err
+		}
+		entry.ID = id
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling history entry: %w", err)
+	}
+
+	if err := os.WriteFile(s.path(entry.ID), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the entries of the store's repository, most recent first.
+// Entries that cannot be read or parsed are skipped and returned as
+// warnings.
+func (s *Store) List() ([]Entry, []error, error) {
+	files, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var entries []Entry
+	var warnings []error
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		entry, err := s.load(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			warnings = append(warnings, err)
+			continue
+		}
+		if entry.Repository != s.Repository {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+
+	return entries, warnings, nil
+}
+
+func (s *Store) Get(id string) (*Entry, error) {
+	entries, warnings, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		if len(warnings) > 0 {
+			return nil, fmt.Errorf("no readable deployment history of %s found in %s: %w",
+				s.Repository, s.Dir, errors.Join(warnings...))
+		}
+		return nil, fmt.Errorf("no deployment history of %s found in %s", s.Repository, s.Dir)
+	}
+
+	if id == LatestID {
+		return &entries[0], nil
+	}
+
+	var matches []Entry
+	for _, entry := range entries {
+		if entry.ID == id {
+			return &entry, nil
+		}
+		if strings.HasPrefix(entry.ID, id) {
+			matches = append(matches, entry)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if len(warnings) > 0 {
+			return nil, fmt.Errorf("history entry '%s' not found (%d unreadable entries skipped)", id, len(warnings))
+		}
+		return nil, fmt.Errorf("history entry '%s' not found", id)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("history entry '%s' is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+func (s *Store) AddRunID(id string, workflow string, runID int64) error {
+	entry, err := s.load(id)
+	if err != nil {
+		return err
+	}
+
+	for i := range entry.Workflows {
+		if entry.Workflows[i].Key != workflow {
+			continue
+		}
+
+		for _, existing := range entry.Workflows[i].RunIDs {
+			if existing == runID {
+				return nil
+			}
+		}
+		entry.Workflows[i].RunIDs = append(entry.Workflows[i].RunIDs, runID)
+		return s.Save(entry)
+	}
+
+	return fmt.Errorf("workflow '%s' not found in history entry '%s'", workflow, id)
+}
+
+func (s *Store) load(id string) (*Entry, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history entry %s: %w", id, err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse history entry %s: %w", id, err)
+	}
+
+	return &entry, nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
+
+func newID(timestamp time.Time) (string, error) {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate history id: %w", err)
+	}
+
+	return fmt.Sprintf("%s-%s", timestamp.Format("20060102-150405"), hex.EncodeToString(suffix)), nil
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inputsource
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/git"
+	"github.com/PraveenGongada/catalyst/internal/history"
+)
+
+type Resolver struct {
+	Config  *config.Config
+	History *history.Store
+	Now     func() time.Time
+
+	// Apps and Platforms are the selection being deployed. Values are only
+	// taken from previous deployments of some of these apps and platforms.
+	Apps      []string
+	Platforms []string
+}
+
+func NewResolver(cfg *config.Config, store *history.Store, apps, platforms []string) *Resolver {
+	return &Resolver{
+		Config:    cfg,
+		History:   store,
+		Now:       time.Now,
+		Apps:      apps,
+		Platforms: platforms,
+	}
+}
+
+// ResolveAll computes the value of every input that declares a source. Inputs
+// whose source cannot be computed are reported in the error map instead.
+func (r *Resolver) ResolveAll() (map[string]string, map[string]error) {
+	values := make(map[string]string)
+	errors := make(map[string]error)
+
+	for name, input := range r.Config.Inputs {
+		if input.Source.Type == "" {
+			continue
+		}
+
+		value, err := r.Resolve(name)
+		if err != nil {
+			errors[name] = err
+			continue
+		}
+		values[name] = value
+	}
+
+	return values, errors
+}
+
+func (r *Resolver) Resolve(name string) (string, error) {
+	input, ok := r.Config.Inputs[name]
+	if !ok {
+		return "", fmt.Errorf("unknown input '%s'", name)
+	}
+
+	source := input.Source
+	switch source.Type {
+	case config.SourceGitTag:
+		return r.fromGitTag(source)
+	case config.SourceHistory:
+		return r.fromHistory(name, source)
+	case config.SourceTimestamp:
+		format := source.Format
+		if format == "" {
+			format = config.DefaultTimestampFormat
+		}
+		return r.Now().UTC().Format(format), nil
+	case "":
+		return input.Default, nil
+	default:
+		return "", fmt.Errorf("unknown source '%s'", source.Type)
+	}
+}
+
+func (r *Resolver) fromGitTag(source config.InputSource) (string, error) {
+	dir := source.Path
+	if dir == "" {
+		dir = "."
+	}
+
+	tags, err := git.Tags(dir, source.TagPrefix+"*")
+	if err != nil {
+		return "", err
+	}
+
+	var versions []version
+	for _, tag := range tags {
+		if v, ok := parseVersion(strings.TrimPrefix(tag, source.TagPrefix)); ok {
+			versions = append(versions, v)
+		}
+	}
+
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no semantic version tags matching '%s*' found", source.TagPrefix)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[j].less(versions[i])
+	})
+
+	return versions[0].bump(source.Bump).String(), nil
+}
+
+func (r *Resolver) fromHistory(name string, source config.InputSource) (string, error) {
+	if r.History == nil {
+		return "", fmt.Errorf("deployment history is not available")
+	}
+
+	entries, _, err := r.History.List()
+	if err != nil {
+		return "", err
+	}
+
+	step := source.Step
+	if step == 0 {
+		step = 1
+	}
+
+	for _, entry := range entries {
+		if !r.deployedSelection(entry) {
+			continue
+		}
+
+		value, ok := entry.Inputs[name]
+		if !ok {
+			continue
+		}
+
+		number, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		return strconv.FormatInt(number+step, 10), nil
+	}
+
+	return "", fmt.Errorf("no previous deployment of the selected apps and platforms in %s "+
+		"with a numeric value for '%s'", r.Config.GitHub.Repository, name)
+}
+
+// deployedSelection reports whether the entry is a deployment from the same
+// repository of some of the selected apps and platforms. An empty selection
+// matches every deployment of the repository.
+func (r *Resolver) deployedSelection(entry history.Entry) bool {
+	if entry.Repository != r.Config.GitHub.Repository {
+		return false
+	}
+	return overlaps(entry.Apps, r.Apps) && overlaps(entry.Platforms, r.Platforms)
+}
+
+func overlaps(deployed, selected []string) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	for _, name := range deployed {
+		if slices.Contains(selected, name) {
+			return true
+		}
+	}
+	return false
+}
+
+type version struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+
+	var v version
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.prerelease = s[i+1:]
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return version{}, false
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		numbers[i] = n
+	}
+
+	v.major, v.minor, v.patch = numbers[0], numbers[1], numbers[2]
+	return v, true
+}
+
+func (v version) less(other version) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	if v.patch != other.patch {
+		return v.patch < other.patch
+	}
+	// A pre-release sorts before the release it precedes.
+	if v.prerelease == "" || other.prerelease == "" {
+		return v.prerelease != "" && other.prerelease == ""
+	}
+	return v.prerelease < other.prerelease
+}
+
+func (v version) bump(kind string) version {
+	switch kind {
+	case config.BumpMajor:
+		return version{major: v.major + 1}
+	case config.BumpMinor:
+		return version{major: v.major, minor: v.minor + 1}
+	case config.BumpNone:
+		return v
+	default:
+		if v.prerelease != "" {
+			return version{major: v.major, minor: v.minor, patch: v.patch}
+		}
+		return version{major: v.major, minor: v.minor, patch: v.patch + 1}
+	}
+}
+
+func (v version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.prerelease != "" {
+		s += "-" + v.prerelease
+	}
+	return s
+}
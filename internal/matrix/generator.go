@@ -18,10 +18,13 @@ package matrix
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/git"
 )
 
 type Generator struct {
@@ -30,12 +33,20 @@ type Generator struct {
 	SelectedPlatforms    []string
 	SelectedEnvironments []string
 	InputValues          map[string]string
+
+	// Branch and Now are the values of the {{git.branch}} and {{now}}
+	// placeholders, except for workflows pinned to their own ref. Commits
+	// maps the refs workflows are dispatched on to their {{git.sha}}.
+	Branch  string
+	Commits map[string]string
+	Now     time.Time
 }
 
 func NewGenerator(cfg *config.Config) *Generator {
 	return &Generator{
 		Config:      cfg,
 		InputValues: make(map[string]string),
+		Now:         time.Now(),
 	}
 }
 
@@ -55,8 +66,140 @@ func (g *Generator) SetInputValue(key, value string) {
 	g.InputValues[key] = value
 }
 
-func (g *Generator) GroupedMatricesWithMetadata() map[string][]map[string]interface{} {
-	result := make(map[string][]map[string]interface{})
+// SetBranch sets the branch workflows are dispatched on. For the selected
+// workflows whose matrices or input templates use {{git.sha}}, the commit
+// of the ref they are dispatched on is looked up in the local repository,
+// which is only possible for workflows of github.repository.
+func (g *Generator) SetBranch(branch string) error {
+	g.Branch = branch
+	g.Commits = make(map[string]string)
+
+	for _, workflow := range g.workflowsUsing(config.NamespaceGit, config.GitSHA) {
+		if repository := g.Config.GitHub.WorkflowRepository(workflow); repository != g.Config.GitHub.Repository {
+			return fmt.Errorf("cannot resolve {{git.sha}} for workflow %s: it is dispatched to %s, "+
+				"which is not the local repository", workflow, repository)
+		}
+
+		ref := g.Config.GitHub.WorkflowRef(workflow, branch)
+		if _, ok := g.Commits[ref]; ok {
+			continue
+		}
+
+		resolved, err := git.ResolveRef(".", ref)
+		if err != nil {
+			return fmt.Errorf("cannot resolve {{git.sha}} for workflow %s: %w", workflow, err)
+		}
+
+		commit, err := git.Run(".", "rev-parse", resolved)
+		if err != nil {
+			return fmt.Errorf("cannot resolve {{git.sha}} for workflow %s: %w", workflow, err)
+		}
+		g.Commits[ref] = commit
+	}
+
+	return nil
+}
+
+// workflowsUsing returns the sorted selected workflows whose matrices or
+// input templates use the placeholder.
+func (g *Generator) workflowsUsing(namespace, name string) []string {
+	seen := make(map[string]bool)
+	var workflows []string
+
+	for _, sel := range g.selections() {
+		workflow := sel.config.Workflow
+		if seen[workflow] {
+			continue
+		}
+
+		for _, value := range g.placeholderValuesOf(sel) {
+			if config.UsesPlaceholder(value, namespace, name) {
+				seen[workflow] = true
+				workflows = append(workflows, workflow)
+				break
+			}
+		}
+	}
+
+	sort.Strings(workflows)
+	return workflows
+}
+
+// placeholderValues returns the values placeholders are resolved in for the
+// selected combinations.
+func (g *Generator) placeholderValues() []string {
+	var values []string
+	for _, sel := range g.selections() {
+		values = append(values, g.placeholderValuesOf(sel)...)
+	}
+	return values
+}
+
+// placeholderValuesOf returns the matrix values of the combination and the
+// input templates of its workflow.
+func (g *Generator) placeholderValuesOf(sel selection) []string {
+	var values []string
+
+	for _, value := range sel.config.Matrix {
+		if strValue, ok := value.(string); ok {
+			values = append(values, strValue)
+		}
+	}
+
+	for _, template := range g.Config.GitHub.Workflows[sel.config.Workflow].Inputs.Templates {
+		values = append(values, template)
+	}
+
+	return values
+}
+
+// envNames returns the sorted names of the environment variables the
+// selected combinations reference with {{env.NAME}}.
+func (g *Generator) envNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, value := range g.placeholderValues() {
+		placeholders, _ := config.Placeholders(value)
+		for _, placeholder := range placeholders {
+			if placeholder.Namespace == config.NamespaceEnv && !seen[placeholder.Name] {
+				seen[placeholder.Name] = true
+				names = append(names, placeholder.Name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// CheckEnv returns an error naming the environment variables that the
+// selected combinations reference with {{env.NAME}} but that are not set.
+func (g *Generator) CheckEnv() error {
+	var missing []string
+	for _, name := range g.envNames() {
+		if _, ok := os.LookupEnv(name); !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("environment variable(s) used by {{env.*}} placeholders not set: %s",
+		strings.Join(missing, ", "))
+}
+
+type selection struct {
+	app         string
+	platform    string
+	environment string
+	config      config.EnvironmentConfig
+}
+
+func (g *Generator) selections() []selection {
+	var selections []selection
 
 	appMap := make(map[string]string)
 	for app := range g.Config.Matrix {
@@ -106,105 +249,226 @@ func (g *Generator) GroupedMatricesWithMetadata() map[string][]map[string]interf
 					continue
 				}
 
-				workflow := envConfig.Workflow
+				selections = append(selections, selection{
+					app:         configApp,
+					platform:    configPlatform,
+					environment: configEnv,
+					config:      envConfig,
+				})
+			}
+		}
+	}
 
-				matrix := map[string]interface{}{
-					"app":         configApp,
-					"platform":    configPlatform,
-					"environment": configEnv,
-				}
+	return selections
+}
 
-				for k, v := range envConfig.Matrix {
-					if strVal, ok := v.(string); ok {
-						matrix[k] = g.Config.SubstituteVariables(strVal, g.InputValues)
-					} else {
-						matrix[k] = v
-					}
-				}
+// PlaceholderContext returns the placeholder values shared by every
+// combination, e.g. for the templates of workflow inputs. Its branch is the
+// deployment's; workflows pinned to a ref replace it with their ref.
+func (g *Generator) PlaceholderContext() config.PlaceholderContext {
+	return config.PlaceholderContext{
+		Inputs:  g.InputValues,
+		Branch:  g.Branch,
+		Commits: g.Commits,
+		Now:     g.Now,
+	}
+}
 
-				if _, ok := result[workflow]; !ok {
-					result[workflow] = []map[string]interface{}{}
-				}
-				result[workflow] = append(result[workflow], matrix)
-			}
+func (g *Generator) substitutedMatrix(sel selection, redactEnv bool) map[string]interface{} {
+	matrix := make(map[string]interface{})
+
+	ctx := g.PlaceholderContext()
+	ctx.RedactEnv = redactEnv
+	ctx.Branch = g.Config.GitHub.WorkflowRef(sel.config.Workflow, g.Branch)
+	ctx.App = sel.app
+	ctx.Platform = sel.platform
+	ctx.Environment = sel.environment
+
+	for k, v := range sel.config.Matrix {
+		if strVal, ok := v.(string); ok {
+			matrix[k] = g.Config.ResolveValue(strVal, ctx)
+		} else {
+			matrix[k] = v
+		}
+	}
+
+	return matrix
+}
+
+func (g *Generator) GroupedMatricesWithMetadata() map[string][]map[string]interface{} {
+	result := make(map[string][]map[string]interface{})
+
+	for _, sel := range g.selections() {
+		matrix := map[string]interface{}{
+			"app":         sel.app,
+			"platform":    sel.platform,
+			"environment": sel.environment,
 		}
+
+		for k, v := range g.substitutedMatrix(sel, false) {
+			matrix[k] = v
+		}
+
+		result[sel.config.Workflow] = append(result[sel.config.Workflow], matrix)
 	}
 
 	return result
 }
 
 func (g *Generator) GroupedMatricesPurified() map[string][]map[string]interface{} {
+	return g.groupedMatrices(false)
+}
+
+// RedactedMatrices returns the matrices of GroupedMatricesPurified with the
+// values of {{env.NAME}} placeholders, which may be secrets, replaced by
+// config.RedactedValue. Other values are left as they are dispatched.
+func (g *Generator) RedactedMatrices() map[string][]map[string]interface{} {
+	return g.groupedMatrices(true)
+}
+
+func (g *Generator) groupedMatrices(redactEnv bool) map[string][]map[string]interface{} {
 	result := make(map[string][]map[string]interface{})
 
-	appMap := make(map[string]string)
-	for app := range g.Config.Matrix {
-		appMap[strings.ToLower(app)] = app
+	for _, sel := range g.selections() {
+		matrix := g.substitutedMatrix(sel, redactEnv)
+		result[sel.config.Workflow] = append(result[sel.config.Workflow], matrix)
 	}
 
-	for _, selectedApp := range g.SelectedApps {
-		configApp := selectedApp
-		if actualApp, ok := appMap[strings.ToLower(selectedApp)]; ok {
-			configApp = actualApp
-		}
+	return result
+}
 
-		appConfig, ok := g.Config.Matrix[configApp]
-		if !ok {
-			continue
+// ProtectedEnvironments returns the sorted names of the selected
+// environments that are marked protected.
+func (g *Generator) ProtectedEnvironments() []string {
+	seen := make(map[string]bool)
+	var environments []string
+
+	for _, sel := range g.selections() {
+		if sel.config.Protected && !seen[sel.environment] {
+			seen[sel.environment] = true
+			environments = append(environments, sel.environment)
 		}
+	}
 
-		platformMap := make(map[string]string)
-		for platform := range appConfig {
-			platformMap[strings.ToLower(platform)] = platform
+	sort.Strings(environments)
+	return environments
+}
+
+// ProtectionPhrase returns what has to be typed to confirm a deployment to
+// protected environments: the environment's name, or the repository's name
+// when several protected environments are selected. It is empty when no
+// protected environment is selected.
+func (g *Generator) ProtectionPhrase() string {
+	protected := g.ProtectedEnvironments()
+
+	switch len(protected) {
+	case 0:
+		return ""
+	case 1:
+		return protected[0]
+	default:
+		return g.Config.GitHub.Repository
+	}
+}
+
+// DisallowedSelections describes the selected combinations whose
+// environment may not be deployed from the ref their workflow is dispatched
+// on for branch, with the refs the environment allows.
+func (g *Generator) DisallowedSelections(branch string) []string {
+	var disallowed []string
+
+	for _, sel := range g.selections() {
+		ref := g.Config.GitHub.WorkflowRef(sel.config.Workflow, branch)
+		if !sel.config.AllowsRef(ref) {
+			disallowed = append(disallowed, fmt.Sprintf("%s/%s/%s from %s (allowed: %s)",
+				sel.app, sel.platform, sel.environment, ref, strings.Join(sel.config.AllowedRefs, ", ")))
 		}
+	}
 
-		for _, selectedPlatform := range g.SelectedPlatforms {
-			configPlatform := selectedPlatform
-			if actualPlatform, ok := platformMap[strings.ToLower(selectedPlatform)]; ok {
-				configPlatform = actualPlatform
-			}
+	return disallowed
+}
 
-			platformConfig, ok := appConfig[configPlatform]
-			if !ok {
-				continue
-			}
+// CheckRefs returns an error listing the selected combinations that may
+// not be deployed from the ref their workflow is dispatched on for branch.
+func (g *Generator) CheckRefs(branch string) error {
+	disallowed := g.DisallowedSelections(branch)
+	if len(disallowed) == 0 {
+		return nil
+	}
 
-			envMap := make(map[string]string)
-			for env := range platformConfig {
-				envMap[strings.ToLower(env)] = env
-			}
+	return fmt.Errorf("%d selected combination(s) may not be deployed from these refs:\n   • %s",
+		len(disallowed), strings.Join(disallowed, "\n   • "))
+}
 
-			for _, selectedEnv := range g.SelectedEnvironments {
-				configEnv := selectedEnv
-				if actualEnv, ok := envMap[strings.ToLower(selectedEnv)]; ok {
-					configEnv = actualEnv
-				}
+// BranchRepositories returns the sorted repositories the selected workflows
+// are dispatched to on the deployment's branch. Workflows pinned to their
+// own ref are left out.
+func (g *Generator) BranchRepositories() []string {
+	seen := make(map[string]bool)
+	var repositories []string
 
-				envConfig, ok := platformConfig[configEnv]
-				if !ok {
-					continue
-				}
+	for _, sel := range g.selections() {
+		if wf, ok := g.Config.GitHub.Workflows[sel.config.Workflow]; ok && wf.Ref != "" {
+			continue
+		}
+
+		repository := g.Config.GitHub.WorkflowRepository(sel.config.Workflow)
+		if !seen[repository] {
+			seen[repository] = true
+			repositories = append(repositories, repository)
+		}
+	}
 
-				workflow := envConfig.Workflow
+	sort.Strings(repositories)
+	return repositories
+}
 
-				matrix := make(map[string]interface{})
+// ActiveFreezes returns the freezes in effect at g.Now that cover at least
+// one of the selected environments.
+func (g *Generator) ActiveFreezes() []config.Freeze {
+	var active []config.Freeze
 
-				for k, v := range envConfig.Matrix {
-					if strVal, ok := v.(string); ok {
-						matrix[k] = g.Config.SubstituteVariables(strVal, g.InputValues)
-					} else {
-						matrix[k] = v
-					}
-				}
+	for _, freeze := range g.Config.Freezes(g.Now) {
+		if !freeze.Active(g.Now) {
+			continue
+		}
+
+		window := g.Config.Freeze[freeze.Name]
+		for _, sel := range g.selections() {
+			if window.Covers(sel.app, sel.environment) {
+				active = append(active, freeze)
+				break
+			}
+		}
+	}
+
+	return active
+}
 
-				if _, ok := result[workflow]; !ok {
-					result[workflow] = []map[string]interface{}{}
+func (g *Generator) ReferencedInputs() []string {
+	inputSet := make(map[string]bool)
+
+	for _, sel := range g.selections() {
+		for _, value := range sel.config.Matrix {
+			if strValue, ok := value.(string); ok {
+				for _, name := range config.InputReferences(strValue) {
+					inputSet[name] = true
 				}
-				result[workflow] = append(result[workflow], matrix)
 			}
 		}
+
+		for _, name := range g.Config.GitHub.Workflows[sel.config.Workflow].InputReferences() {
+			inputSet[name] = true
+		}
 	}
 
-	return result
+	inputs := make([]string, 0, len(inputSet))
+	for input := range inputSet {
+		inputs = append(inputs, input)
+	}
+	sort.Strings(inputs)
+
+	return inputs
 }
 
 func (g *Generator) GroupedMatrices() map[string][]map[string]interface{} {
@@ -0,0 +1,313 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package notify calls the webhooks of the notifications section when a
+// deployment is dispatched and when its runs have finished.
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/catalyst/internal/audit"
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
+)
+
+const (
+	StatusDispatched = "dispatched"
+	StatusFailed     = "failed"
+	StatusSuccess    = "success"
+
+	requestTimeout = 10 * time.Second
+)
+
+// defaultBodies are sent by the formats when a notification has no body.
+var defaultBodies = map[string]string{
+	config.NotificationFormatGeneric: `{{json .}}`,
+	config.NotificationFormatSlack:   `{"text": {{json .Text}}}`,
+	config.NotificationFormatTeams: `{
+  "@type": "MessageCard",
+  "@context": "https://schema.org/extensions",
+  "themeColor": {{json .Color}},
+  "summary": {{json .Title}},
+  "title": {{json .Title}},
+  "sections": [{
+    "facts": [
+      {"name": "Apps", "value": {{json (join .Apps ", ")}}},
+      {"name": "Platforms", "value": {{json (join .Platforms ", ")}}},
+      {"name": "Environments", "value": {{json (join .Environments ", ")}}},
+      {"name": "Branch", "value": {{json .Branch}}},
+      {"name": "Workflows", "value": {{json (join .WorkflowLines "  \n")}}}
+    ],
+    "text": {{json .ChangeLog}}
+  }]
+}`,
+}
+
+// Message is the data notification templates are rendered with.
+type Message struct {
+	Event        string     `json:"event"`
+	Repositories []string   `json:"repositories"`
+	User         string     `json:"user"`
+	Apps         []string   `json:"apps"`
+	Platforms    []string   `json:"platforms"`
+	Environments []string   `json:"environments"`
+	Branch       string     `json:"branch"`
+	ChangeLog    string     `json:"changelog"`
+	Workflows    []Workflow `json:"workflows"`
+}
+
+// Workflow is a dispatch of the deployment. Its status is dispatched or
+// failed when the deployment is dispatched, and the conclusion of its run
+// (success, failure, cancelled, ...) once the run has finished.
+type Workflow struct {
+	Name       string `json:"name"`
+	Workflow   string `json:"workflow"`
+	File       string `json:"file"`
+	Repository string `json:"repository"`
+	Ref        string `json:"ref"`
+	Status     string `json:"status"`
+	URL        string `json:"url,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewMessage describes a deployment that has just been dispatched. Its
+// repositories are those the workflows were dispatched to, in the order of
+// the results.
+func NewMessage(
+	cfg *config.Config,
+	apps, platforms, environments []string,
+	branch, changeLog string,
+	results []dispatch.Result,
+) Message {
+	message := Message{
+		Event:        config.NotificationDispatched,
+		User:         audit.LocalUser(),
+		Apps:         apps,
+		Platforms:    platforms,
+		Environments: environments,
+		Branch:       branch,
+		ChangeLog:    changeLog,
+	}
+
+	seen := make(map[string]bool)
+	for _, result := range results {
+		if !seen[result.Repository] {
+			seen[result.Repository] = true
+			message.Repositories = append(message.Repositories, result.Repository)
+		}
+
+		workflow := Workflow{
+			Name:       WorkflowName(cfg, result),
+			Workflow:   result.Workflow,
+			File:       result.File,
+			Repository: result.Repository,
+			Ref:        result.Branch,
+			Status:     StatusDispatched,
+		}
+		if result.Error != nil {
+			workflow.Status = StatusFailed
+			workflow.Error = result.Error.Error()
+		}
+		message.Workflows = append(message.Workflows, workflow)
+	}
+
+	return message
+}
+
+// WorkflowName is the display name of the workflow of a dispatch, including
+// the part if its matrices were split.
+func WorkflowName(cfg *config.Config, result dispatch.Result) string {
+	name := result.Workflow
+	if wf, ok := cfg.GitHub.Workflows[result.Workflow]; ok && wf.Name != "" {
+		name = wf.Name
+	}
+	if part := result.Part(); part != "" {
+		name = fmt.Sprintf("%s (%s)", name, part)
+	}
+	return name
+}
+
+// Succeeded reports whether no workflow failed.
+func (m Message) Succeeded() bool {
+	for _, workflow := range m.Workflows {
+		if workflow.Status != StatusDispatched && workflow.Status != StatusSuccess {
+			return false
+		}
+	}
+	return true
+}
+
+// Title summarises the deployment in a single line.
+func (m Message) Title() string {
+	target := fmt.Sprintf("%s (%s) to %s from %s",
+		strings.Join(m.Apps, ", "),
+		strings.Join(m.Platforms, ", "),
+		strings.Join(m.Environments, ", "),
+		m.Branch,
+	)
+
+	switch {
+	case m.Event == config.NotificationCompleted && m.Succeeded():
+		return "✅ Deployed " + target
+	case m.Event == config.NotificationCompleted:
+		return "❌ Deployment of " + target + " failed"
+	case m.Succeeded():
+		return "🚀 Deploying " + target
+	default:
+		return "⚠️ Deploying " + target + " with failed dispatches"
+	}
+}
+
+// Color is a hex color matching the outcome, for cards that support one.
+func (m Message) Color() string {
+	switch {
+	case !m.Succeeded():
+		return "D93F0B"
+	case m.Event == config.NotificationCompleted:
+		return "2EA043"
+	default:
+		return "0969DA"
+	}
+}
+
+// WorkflowLines lists the workflows and their status, one per line.
+func (m Message) WorkflowLines() []string {
+	lines := make([]string, 0, len(m.Workflows))
+	for _, workflow := range m.Workflows {
+		line := fmt.Sprintf("%s: %s", workflow.Name, workflow.Status)
+		if workflow.URL != "" {
+			line += " " + workflow.URL
+		}
+		if workflow.Error != "" {
+			line += " (" + workflow.Error + ")"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Text is a plain text rendering of the message.
+func (m Message) Text() string {
+	var text strings.Builder
+
+	text.WriteString(m.Title() + "\n")
+	if m.User != "" {
+		text.WriteString("Triggered by " + m.User + " in " + strings.Join(m.Repositories, ", ") + "\n")
+	}
+	text.WriteString("\n")
+
+	for _, line := range m.WorkflowLines() {
+		text.WriteString("• " + line + "\n")
+	}
+
+	if m.ChangeLog != "" {
+		text.WriteString("\n" + m.ChangeLog + "\n")
+	}
+
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// Send calls every webhook subscribed to the message's event. A failing
+// webhook does not keep the others from being called; their errors are
+// returned together.
+func Send(cfg *config.Config, message Message) error {
+	client := &http.Client{Timeout: requestTimeout}
+
+	var errs []error
+	for _, name := range cfg.NotificationsFor(message.Event) {
+		if err := send(client, cfg.Notifications[name], message); err != nil {
+			errs = append(errs, fmt.Errorf("notification %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func send(client *http.Client, notification config.NotificationConfig, message Message) error {
+	target, err := render("url", notification.URL, message)
+	if err != nil {
+		return err
+	}
+
+	body := notification.Body
+	if body == "" {
+		format := notification.Format
+		if format == "" {
+			format = config.NotificationFormatGeneric
+		}
+		body = defaultBodies[format]
+	}
+
+	payload, err := render("body", body, message)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSpace(target), strings.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "catalyst")
+
+	for header, value := range notification.Headers {
+		rendered, err := render(header, value, message)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(header, rendered)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// The URL may contain a secret, so only the host is reported.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to call webhook at %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if text := strings.TrimSpace(string(detail)); text != "" {
+			return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, text)
+		}
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func render(name, text string, message Message) (string, error) {
+	tmpl, err := config.ParseNotificationTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, message); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return rendered.String(), nil
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
+)
+
+// request is a webhook call received by the test server.
+type request struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newServer(t *testing.T, status int, response string) (*httptest.Server, <-chan request) {
+	t.Helper()
+
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{path: r.URL.Path, header: r.Header, body: body}
+		w.WriteHeader(status)
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func testMessage() Message {
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{
+			Repository: "org/app",
+			Workflows: map[string]config.WorkflowConfig{
+				"build": {Name: "Build", File: "build.yml"},
+			},
+		},
+	}
+
+	return NewMessage(cfg, []string{"app"}, []string{"android"}, []string{"prod"}, "main", `Fix "quotes"`,
+		[]dispatch.Result{
+			{Workflow: "build", File: "build.yml", Repository: "org/app", Branch: "main"},
+			{Workflow: "deploy", File: "deploy.yml", Repository: "org/infra", Branch: "v1",
+				Error: errors.New("boom")},
+		})
+}
+
+func TestSendDefaultBodies(t *testing.T) {
+	message := testMessage()
+
+	tests := []struct {
+		format string
+		check  func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			format: config.NotificationFormatGeneric,
+			check: func(t *testing.T, body map[string]interface{}) {
+				if body["event"] != config.NotificationDispatched || body["branch"] != "main" {
+					t.Errorf("unexpected message %v", body)
+				}
+				repositories, _ := body["repositories"].([]interface{})
+				if len(repositories) != 2 || repositories[0] != "org/app" || repositories[1] != "org/infra" {
+					t.Errorf("repositories = %v", body["repositories"])
+				}
+				workflows, _ := body["workflows"].([]interface{})
+				if len(workflows) != 2 {
+					t.Fatalf("workflows = %v", body["workflows"])
+				}
+				if failed, _ := workflows[1].(map[string]interface{}); failed["status"] != StatusFailed ||
+					failed["error"] != "boom" {
+					t.Errorf("failed workflow = %v", failed)
+				}
+			},
+		},
+		{
+			format: config.NotificationFormatSlack,
+			check: func(t *testing.T, body map[string]interface{}) {
+				if body["text"] != message.Text() {
+					t.Errorf("text = %q, want %q", body["text"], message.Text())
+				}
+			},
+		},
+		{
+			format: config.NotificationFormatTeams,
+			check: func(t *testing.T, body map[string]interface{}) {
+				if body["@type"] != "MessageCard" || body["title"] != message.Title() ||
+					body["themeColor"] != message.Color() {
+					t.Errorf("unexpected card %v", body)
+				}
+				sections, _ := body["sections"].([]interface{})
+				if len(sections) != 1 {
+					t.Fatalf("sections = %v", body["sections"])
+				}
+				section, _ := sections[0].(map[string]interface{})
+				if section["text"] != `Fix "quotes"` {
+					t.Errorf("section text = %v", section["text"])
+				}
+				if facts, _ := section["facts"].([]interface{}); len(facts) != 5 {
+					t.Errorf("facts = %v", section["facts"])
+				}
+			},
+		},
+		{
+			format: "",
+			check: func(t *testing.T, body map[string]interface{}) {
+				if body["event"] != config.NotificationDispatched {
+					t.Errorf("empty format did not send the generic body: %v", body)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("format "+tt.format, func(t *testing.T) {
+			server, requests := newServer(t, http.StatusOK, "")
+			cfg := &config.Config{Notifications: map[string]config.NotificationConfig{
+				"hook": {URL: server.URL + "/hook", Format: tt.format},
+			}}
+
+			if err := Send(cfg, message); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := <-requests
+			if contentType := got.header.Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Content-Type = %q", contentType)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(got.body, &body); err != nil {
+				t.Fatalf("body is not valid JSON: %v\n%s", err, got.body)
+			}
+			tt.check(t, body)
+		})
+	}
+}
+
+func TestSendTemplates(t *testing.T) {
+	t.Setenv("CATALYST_TEST_HOOK_TOKEN", "s3cret")
+	server, requests := newServer(t, http.StatusNoContent, "")
+
+	cfg := &config.Config{Notifications: map[string]config.NotificationConfig{
+		"hook": {
+			URL: server.URL + "/{{.Event}}",
+			Headers: map[string]string{
+				"Authorization": `Bearer {{env "CATALYST_TEST_HOOK_TOKEN"}}`,
+				"X-Branch":      "{{.Branch}}",
+			},
+			Body: `{"apps": {{json (join .Apps ",")}}}`,
+		},
+	}}
+
+	if err := Send(cfg, testMessage()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := <-requests
+	if got.path != "/dispatched" {
+		t.Errorf("path = %q, want /dispatched", got.path)
+	}
+	if auth := got.header.Get("Authorization"); auth != "Bearer s3cret" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if branch := got.header.Get("X-Branch"); branch != "main" {
+		t.Errorf("X-Branch = %q", branch)
+	}
+	if string(got.body) != `{"apps": "app"}` {
+		t.Errorf("body = %s", got.body)
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	const secret = "T000/B000/s3cret"
+
+	failing, _ := newServer(t, http.StatusInternalServerError, "invalid_token\n")
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		wantText []string
+	}{
+		{
+			name:     "non-2xx",
+			url:      failing.URL + "/services/" + secret,
+			wantText: []string{"notification hook", "status 500", "invalid_token"},
+		},
+		{
+			name:     "unreachable",
+			url:      closed.URL + "/services/" + secret + "?token=" + secret,
+			wantText: []string{"notification hook", "failed to call webhook at " + strings.TrimPrefix(closed.URL, "http://")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Notifications: map[string]config.NotificationConfig{
+				"hook": {URL: tt.url},
+			}}
+
+			err := Send(cfg, testMessage())
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, text := range tt.wantText {
+				if !strings.Contains(err.Error(), text) {
+					t.Errorf("error %q does not contain %q", err, text)
+				}
+			}
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("error %q leaks the webhook URL", err)
+			}
+		})
+	}
+}
+
+func TestSendEvents(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK, "")
+
+	cfg := &config.Config{Notifications: map[string]config.NotificationConfig{
+		"dispatched": {URL: server.URL + "/dispatched"},
+		"completed":  {URL: server.URL + "/completed", Events: []string{config.NotificationCompleted}},
+	}}
+
+	message := testMessage()
+	message.Event = config.NotificationCompleted
+	if err := Send(cfg, message); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := <-requests; got.path != "/completed" {
+		t.Errorf("called %s for a completion", got.path)
+	}
+	select {
+	case got := <-requests:
+		t.Errorf("unexpected call to %s", got.path)
+	default:
+	}
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package refs lists the branches and tags a deployment can be dispatched
+// on in each target repository, and finds the closest names to a mistyped
+// one.
+package refs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/git"
+	"github.com/PraveenGongada/catalyst/internal/github"
+)
+
+const (
+	SourceGitHub = "GitHub"
+	SourceGit    = "local git"
+)
+
+// Refs are the branch and tag names of a repository.
+type Refs struct {
+	Repository string
+	Names      []string
+	Source     string
+	// Truncated is set when the repository has more refs than were listed.
+	Truncated bool
+}
+
+// Load lists the branches and tags of the repository through the GitHub
+// API. When the API is not available (e.g. in dry-run mode) and the
+// repository is github.repository, it falls back to the remote-tracking
+// branches and tags of the local repository at changelog.path.
+func Load(cfg *config.Config, client github.Client, repository string) (Refs, error) {
+	branches, truncated, apiErr := github.ListBranches(client, repository)
+	if apiErr == nil {
+		var tags []string
+		var tagsTruncated bool
+		tags, tagsTruncated, apiErr = github.ListTags(client, repository)
+		if apiErr == nil {
+			refs := newRefs(repository, append(branches, tags...), SourceGitHub)
+			refs.Truncated = truncated || tagsTruncated
+			return refs, nil
+		}
+	}
+
+	if repository != cfg.GitHub.Repository {
+		return Refs{}, fmt.Errorf("could not list the branches of %s: %v", repository, apiErr)
+	}
+
+	names, gitErr := git.RemoteRefs(cfg.Changelog.Path)
+	if gitErr != nil {
+		return Refs{}, fmt.Errorf("could not list the branches of %s: %v; %v", repository, apiErr, gitErr)
+	}
+	return newRefs(repository, names, SourceGit), nil
+}
+
+func newRefs(repository string, names []string, source string) Refs {
+	sort.Strings(names)
+	return Refs{Repository: repository, Names: names, Source: source}
+}
+
+// Contains reports whether name is one of the refs.
+func (r Refs) Contains(name string) bool {
+	i := sort.SearchStrings(r.Names, name)
+	return i < len(r.Names) && r.Names[i] == name
+}
+
+// Conclusive reports whether a ref missing from the refs does not exist in
+// the repository. Refs of the local clone may be stale and truncated lists
+// incomplete, so a ref missing from them may still exist.
+func (r Refs) Conclusive() bool {
+	return r.Source == SourceGitHub && !r.Truncated
+}
+
+// Check returns an error naming the source and the closest refs if ref is
+// not one of the refs. Empty refs, e.g. of a clone without remote-tracking
+// branches, do not reject anything.
+func (r Refs) Check(ref string) error {
+	if len(r.Names) == 0 || r.Contains(ref) {
+		return nil
+	}
+
+	var message string
+	switch {
+	case r.Source != SourceGitHub:
+		message = fmt.Sprintf("%s was not found in the %s refs of %s", ref, r.Source, r.Repository)
+	case r.Truncated:
+		message = fmt.Sprintf("%s was not found in the first %d refs of %s on %s",
+			ref, len(r.Names), r.Repository, r.Source)
+	default:
+		message = fmt.Sprintf("%s does not exist in %s on %s", ref, r.Repository, r.Source)
+	}
+	if closest := r.Closest(ref, 3); len(closest) > 0 {
+		message += "; did you mean " + strings.Join(closest, ", ") + "?"
+	}
+	return errors.New(message)
+}
+
+// Targets are the refs of the repositories a branch is dispatched to.
+type Targets struct {
+	Lists []Refs
+	// Errors are for the repositories whose refs could not be listed.
+	Errors []error
+}
+
+// LoadTargets lists the refs of each repository.
+func LoadTargets(cfg *config.Config, client github.Client, repositories []string) Targets {
+	var targets Targets
+	for _, repository := range repositories {
+		list, err := Load(cfg, client, repository)
+		if err != nil {
+			targets.Errors = append(targets.Errors, err)
+			continue
+		}
+		targets.Lists = append(targets.Lists, list)
+	}
+	return targets
+}
+
+// Check checks that ref exists in every repository. A ref missing from a
+// conclusive list is an error; missing from another list, or a list that
+// could not be loaded, is only a warning.
+func (t Targets) Check(ref string) (warnings []string, err error) {
+	for _, loadErr := range t.Errors {
+		warnings = append(warnings, loadErr.Error())
+	}
+
+	var errs []error
+	for _, list := range t.Lists {
+		if checkErr := list.Check(ref); checkErr == nil {
+			continue
+		} else if list.Conclusive() {
+			errs = append(errs, checkErr)
+		} else {
+			warnings = append(warnings, checkErr.Error())
+		}
+	}
+
+	return warnings, errors.Join(errs...)
+}
+
+// Merged returns the refs of all repositories as one list, e.g. to complete
+// the branch name from.
+func (t Targets) Merged() Refs {
+	seen := make(map[string]bool)
+	var merged Refs
+
+	for _, list := range t.Lists {
+		for _, name := range list.Names {
+			if !seen[name] {
+				seen[name] = true
+				merged.Names = append(merged.Names, name)
+			}
+		}
+	}
+
+	sort.Strings(merged.Names)
+	return merged
+}
+
+// Closest returns up to limit refs resembling query, best first: refs that
+// contain the query's characters in order, then refs within a few typos.
+func (r Refs) Closest(query string, limit int) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	type match struct {
+		name  string
+		score int
+	}
+
+	maxDistance := max(1, len(query)/3)
+
+	var matches []match
+	for _, name := range r.Names {
+		candidate := strings.ToLower(name)
+
+		if gaps, ok := subsequenceGaps(query, candidate); ok {
+			score := gaps + len(candidate) - len(query)
+			if !strings.HasPrefix(candidate, query) {
+				score += len(candidate)
+			}
+			matches = append(matches, match{name: name, score: score})
+			continue
+		}
+
+		if distance := editDistance(query, candidate); distance <= maxDistance {
+			matches = append(matches, match{name: name, score: 1000 + distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score < matches[j].score
+	})
+
+	var names []string
+	for i := 0; i < len(matches) && i < limit; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// subsequenceGaps reports whether the characters of query appear in order
+// in candidate, and how many other characters lie between them.
+func subsequenceGaps(query, candidate string) (int, bool) {
+	gaps, position := 0, 0
+
+	for i, r := range query {
+		offset := strings.IndexRune(candidate[position:], r)
+		if offset < 0 {
+			return 0, false
+		}
+		if i > 0 {
+			gaps += offset
+		}
+		position += offset + len(string(r))
+	}
+
+	return gaps, true
+}
+
+// editDistance is the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
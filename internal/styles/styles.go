@@ -74,3 +74,26 @@ var SummaryDividerStyle = lipgloss.NewStyle().
 var SummaryFooterStyle = lipgloss.NewStyle().
 	Foreground(lipgloss.Color("15")).
 	Align(lipgloss.Center).Width(55)
+
+var RunSuccessStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#90EE90"))
+
+var RunFailureStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#D9534F"))
+
+var RunPendingStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#F0AD4E"))
+
+var RunMutedStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("241"))
+
+var TableHeaderStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#5ea1ff")).
+	Padding(0, 1)
+
+var TableCellStyle = lipgloss.NewStyle().
+	Padding(0, 1)
+
+var TableBorderStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("241"))
@@ -24,19 +24,36 @@ import (
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
 	"github.com/PraveenGongada/catalyst/internal/matrix"
+	"github.com/PraveenGongada/catalyst/internal/notify"
 	"github.com/PraveenGongada/catalyst/internal/styles"
 	"github.com/PraveenGongada/catalyst/internal/types"
 )
 
 type TriggerMsg struct {
-	error error
+	results      []dispatch.Result
+	historyID    string
+	notification *notify.Message
+	notifyError  error
+	error        error
 }
 
+// confirmStep is a confirmation typed before the deployment is triggered.
+type confirmStep int
+
+const (
+	confirmNone confirmStep = iota
+	confirmFreeze
+	confirmProtected
+)
+
 type ConfirmModel struct {
 	mainModel       *MainModel
 	viewport        viewport.Model
@@ -49,6 +66,18 @@ type ConfirmModel struct {
 	showPreview     bool
 	matrixGenerator *matrix.Generator
 	summaryContent  string
+
+	// summaryError is a problem with the selections found while building
+	// the summary, e.g. an environment that may not be deployed from the
+	// branch. It prevents triggering.
+	summaryError error
+
+	// confirmStep is set while a freeze override reason or the name of the
+	// protected environment (or repository) is being typed.
+	confirmStep  confirmStep
+	confirmation textinput.Model
+	confirmError string
+	freezeReason string
 }
 
 func NewConfirmModel(m *MainModel) *ConfirmModel {
@@ -60,6 +89,9 @@ func NewConfirmModel(m *MainModel) *ConfirmModel {
 
 	h := help.New()
 
+	ti := textinput.New()
+	ti.Prompt = "> "
+
 	generator := matrix.NewGenerator(m.config)
 
 	generator.SetSelectedApps(m.GetSelectedApps())
@@ -82,6 +114,7 @@ func NewConfirmModel(m *MainModel) *ConfirmModel {
 		triggered:       false,
 		showPreview:     false,
 		matrixGenerator: generator,
+		confirmation:    ti,
 	}
 	return model
 }
@@ -111,11 +144,36 @@ func (m *ConfirmModel) View() string {
 	helpView := strings.Join(helpParts, " • ")
 
 	if m.isLoading {
+		action := "Triggering GitHub Action Workflows"
+		if _, dryRun := m.mainModel.dryRunDir(); dryRun {
+			action = "Writing dispatch payloads"
+		}
 		return viewportContent + "\n\n" + styles.GitHubMessageStyle.Render(
-			fmt.Sprintf("Triggering GitHub Action Workflows %s", m.spinner.View()),
+			fmt.Sprintf("%s %s", action, m.spinner.View()),
+		)
+	} else if m.confirmStep != confirmNone {
+		question := fmt.Sprintf(
+			"This deployment includes protected environments. Type %q to confirm:",
+			m.matrixGenerator.ProtectionPhrase(),
 		)
+		if m.confirmStep == confirmFreeze {
+			question = "A deployment freeze is in effect. Enter the reason for overriding it:"
+		}
+
+		prompt := styles.GitHubMessageStyle.Render(question) + "\n" + m.confirmation.View()
+		if m.confirmError != "" {
+			prompt += "\n" + styles.GitHubErrorStyle.Render(m.confirmError)
+		}
+		return viewportContent + "\n\n" + prompt + "\n\n" +
+			styles.CustomHelpStyle.Render("enter: confirm • esc: cancel")
 	} else if m.error != nil {
 		return viewportContent + "\n\n" + styles.GitHubErrorStyle.Render("Error Triggering GitHub Action: "+m.error.Error())
+	} else if m.summaryError != nil {
+		return viewportContent + "\n\n" + styles.GitHubErrorStyle.Render("Cannot trigger: "+m.summaryError.Error())
+	} else if outputDir, dryRun := m.mainModel.dryRunDir(); dryRun && m.triggered {
+		return viewportContent + "\n\n" + styles.GitHubMessageStyle.Render(
+			"Dry run: dispatch payloads written to "+outputDir,
+		)
 	} else if m.triggered {
 		return viewportContent + "\n\n" + styles.GitHubMessageStyle.Render("GitHub Action Workflows Triggered Successfully!")
 	}
@@ -131,6 +189,10 @@ func (m *ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewport.Height = msg.Height - 4
 
 	case tea.KeyMsg:
+		if m.confirmStep != confirmNone && !m.isLoading {
+			return m.updateConfirmation(msg)
+		}
+
 		if !m.isLoading {
 			if m.showPreview {
 				switch msg.String() {
@@ -156,8 +218,7 @@ func (m *ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.triggered {
 					return m, nil
 				}
-				m.isLoading = true
-				return m, tea.Batch(m.spinner.Tick, triggerAction(m.mainModel))
+				return m.nextConfirmation(confirmNone)
 
 			case "ctrl+p", "left":
 				m.mainModel.moveToPreviousStage()
@@ -194,12 +255,29 @@ func (m *ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case TriggerMsg:
 		m.isLoading = false
+		if len(msg.results) > 0 {
+			m.viewport.SetContent(lipgloss.JoinVertical(
+				lipgloss.Left,
+				m.summaryContent,
+				dispatchTable(m.mainModel, msg.results),
+			))
+			m.viewport.GotoBottom()
+		}
 		if msg.error != nil {
 			m.error = msg.error
 			m.triggered = false
 			return m, nil
 		}
 		m.triggered = true
+		if _, dryRun := m.mainModel.dryRunDir(); dryRun {
+			return m, nil
+		}
+		m.mainModel.dispatchResults = msg.results
+		m.mainModel.historyID = msg.historyID
+		m.mainModel.notification = msg.notification
+		m.mainModel.notifyError = msg.notifyError
+		m.mainModel.moveToNextStage()
+		return m.mainModel, m.mainModel.Init()
 	case spinner.TickMsg:
 		var spinnerCmd tea.Cmd
 		m.spinner, spinnerCmd = m.spinner.Update(msg)
@@ -213,6 +291,93 @@ func (m *ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// nextConfirmation asks for the confirmation required after the given one,
+// or triggers the deployment once nothing else is required. A freeze that
+// cannot be overridden, or a problem found by the summary, stops the
+// deployment.
+func (m *ConfirmModel) nextConfirmation(after confirmStep) (tea.Model, tea.Cmd) {
+	m.confirmStep = confirmNone
+	m.confirmError = ""
+	m.confirmation.SetValue("")
+	m.confirmation.Blur()
+
+	if m.summaryError != nil {
+		return m, nil
+	}
+
+	if freezes := m.matrixGenerator.ActiveFreezes(); after < confirmFreeze && len(freezes) > 0 {
+		if !config.Overridable(freezes) {
+			m.error = config.CheckFreezes(freezes, "")
+			return m, nil
+		}
+		m.confirmStep = confirmFreeze
+		return m, m.confirmation.Focus()
+	}
+
+	if after < confirmProtected && m.matrixGenerator.ProtectionPhrase() != "" {
+		m.confirmStep = confirmProtected
+		return m, m.confirmation.Focus()
+	}
+
+	m.isLoading = true
+	return m, tea.Batch(m.spinner.Tick, triggerAction(m.mainModel))
+}
+
+// updateConfirmation handles the keys typed while the deployment waits for
+// a freeze override reason or the protection phrase.
+func (m *ConfirmModel) updateConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Interrupt
+
+	case "esc":
+		m.confirmStep = confirmNone
+		m.confirmation.Blur()
+		return m, nil
+
+	case "enter":
+		value := strings.TrimSpace(m.confirmation.Value())
+
+		if m.confirmStep == confirmFreeze {
+			if value == "" {
+				m.confirmError = "an override reason is required"
+				return m, nil
+			}
+			m.freezeReason = value
+			return m.nextConfirmation(confirmFreeze)
+		}
+
+		if phrase := m.matrixGenerator.ProtectionPhrase(); value != phrase {
+			m.confirmError = fmt.Sprintf("%q does not match %q", value, phrase)
+			return m, nil
+		}
+		return m.nextConfirmation(confirmProtected)
+	}
+
+	var cmd tea.Cmd
+	m.confirmation, cmd = m.confirmation.Update(msg)
+	m.confirmError = ""
+	return m, cmd
+}
+
+// chunkSummary describes how the matrices of a workflow are split into
+// separate dispatches, or is empty when they are dispatched at once.
+func chunkSummary(wf config.WorkflowConfig, matrices []map[string]interface{}) string {
+	chunks, err := dispatch.Chunks(wf, matrices)
+	if err != nil {
+		return ", " + styles.GitHubErrorStyle.Render(err.Error())
+	}
+	if len(chunks) <= 1 {
+		return ""
+	}
+
+	sizes := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		sizes[i] = fmt.Sprint(len(chunk))
+	}
+	return fmt.Sprintf(", split into %d dispatches of %s", len(chunks), strings.Join(sizes, " + "))
+}
+
 func (m *ConfirmModel) generateAllMatricesPreview() string {
 	groupedMatrices := m.matrixGenerator.GroupedMatricesWithMetadata()
 
@@ -351,6 +516,13 @@ func (m *ConfirmModel) DeploymentSummary() string {
 		for key, value := range inputsModel.GetInputValues() {
 			m.matrixGenerator.SetInputValue(key, value)
 		}
+		m.summaryError = m.matrixGenerator.SetBranch(inputsModel.GetBranchName())
+		if m.summaryError == nil {
+			m.summaryError = m.matrixGenerator.CheckRefs(inputsModel.GetBranchName())
+		}
+		if m.summaryError == nil {
+			m.summaryError = m.matrixGenerator.CheckEnv()
+		}
 	}
 
 	groupedMatrices := m.matrixGenerator.GroupedMatricesWithMetadata()
@@ -367,12 +539,23 @@ func (m *ConfirmModel) DeploymentSummary() string {
 		styles.SummaryValueStyle.Render(strings.Join(mainModel.GetSelectedApps(), "\n   • ")),
 	)
 
+	protected := make(map[string]bool)
+	for _, env := range m.matrixGenerator.ProtectedEnvironments() {
+		protected[env] = true
+	}
+
+	var environments []string
+	for _, env := range mainModel.GetSelectedEnvironments() {
+		if protected[env] {
+			env += " 🔒 protected"
+		}
+		environments = append(environments, env)
+	}
+
 	envs := fmt.Sprintf(
 		"%s\n   • %s",
 		styles.SummaryTitleStyle.Render("📂 Environments"),
-		styles.SummaryValueStyle.Render(
-			strings.Join(mainModel.GetSelectedEnvironments(), "\n   • "),
-		),
+		styles.SummaryValueStyle.Render(strings.Join(environments, "\n   • ")),
 	)
 
 	platforms := fmt.Sprintf(
@@ -397,27 +580,64 @@ func (m *ConfirmModel) DeploymentSummary() string {
 		}
 	}
 
+	branchName := "main"
+	if inputsModel != nil {
+		branchName = inputsModel.GetBranchName()
+	}
+
 	var workflowsText strings.Builder
 	workflowsText.WriteString(styles.SummaryTitleStyle.Render("🔄 Workflows To Trigger"))
 
 	if totalCombinations == 0 {
 		workflowsText.WriteString("\n   • No workflows will be triggered based on your selections")
 	} else {
-		for workflow, matrices := range groupedMatrices {
-			if len(matrices) == 0 {
-				continue
-			}
+		github := mainModel.config.GitHub
 
-			workflowName := workflow
-			if wf, ok := mainModel.config.GitHub.Workflows[workflow]; ok {
-				workflowName = wf.Name
-			}
+		byRepository := make(map[string][]string)
+		for _, workflow := range dispatch.SortedWorkflows(groupedMatrices) {
+			repository := github.WorkflowRepository(workflow)
+			byRepository[repository] = append(byRepository[repository], workflow)
+		}
 
-			workflowsText.WriteString(fmt.Sprintf("\n   • %s (%d matrix combinations)",
-				styles.SummaryTitleStyle.Render(workflowName),
-				len(matrices),
+		var repositories []string
+		for repository := range byRepository {
+			repositories = append(repositories, repository)
+		}
+		sort.Strings(repositories)
+
+		for _, repository := range repositories {
+			workflowsText.WriteString(fmt.Sprintf("\n   📦 %s",
+				styles.SummaryValueStyle.Render(repository),
 			))
+
+			for _, workflow := range byRepository[repository] {
+				workflowName := workflow
+				if wf, ok := github.Workflows[workflow]; ok && wf.Name != "" {
+					workflowName = wf.Name
+				}
+
+				workflowsText.WriteString(fmt.Sprintf("\n      • %s on %s (%d matrix combinations%s)",
+					styles.SummaryTitleStyle.Render(workflowName),
+					styles.SummaryValueStyle.Render(github.WorkflowRef(workflow, branchName)),
+					len(groupedMatrices[workflow]),
+					chunkSummary(github.Workflows[workflow], groupedMatrices[workflow]),
+				))
+			}
+		}
+	}
+
+	var freezeText string
+	if freezes := m.matrixGenerator.ActiveFreezes(); len(freezes) > 0 {
+		descriptions := make([]string, len(freezes))
+		for i, freeze := range freezes {
+			descriptions[i] = freeze.String()
 		}
+
+		freezeText = fmt.Sprintf(
+			"%s\n   • %s\n\n",
+			styles.SummaryTitleStyle.Render("❄️ Deployment Freeze"),
+			styles.RunFailureStyle.Render(strings.Join(descriptions, "\n   • ")),
+		)
 	}
 
 	var changelogText string
@@ -440,15 +660,23 @@ func (m *ConfirmModel) DeploymentSummary() string {
 		)
 	}
 
+	question := fmt.Sprintf(
+		"Would you like to proceed with triggering %d matrix combinations?\n",
+		totalCombinations,
+	)
+	if freezes := m.matrixGenerator.ActiveFreezes(); len(freezes) > 0 {
+		if config.Overridable(freezes) {
+			question += "A deployment freeze is in effect; you will be asked for an override reason.\n"
+		} else {
+			question += "A deployment freeze is in effect; the deployment will be refused.\n"
+		}
+	}
+	if phrase := m.matrixGenerator.ProtectionPhrase(); phrase != "" {
+		question += fmt.Sprintf("Protected environments are selected; you will be asked to type %q.\n", phrase)
+	}
+
 	footer := styles.SummaryFooterStyle.Render(
-		fmt.Sprintf(
-			"%s\n%s",
-			fmt.Sprintf(
-				"Would you like to proceed with triggering %d matrix combinations?\n",
-				totalCombinations,
-			),
-			"[Y] Yes    [N] No",
-		),
+		fmt.Sprintf("%s\n%s", question, "[Y] Yes    [N] No"),
 	)
 
 	return lipgloss.JoinVertical(
@@ -469,7 +697,7 @@ func (m *ConfirmModel) DeploymentSummary() string {
 		space,
 		workflowsText.String(),
 		space,
-		changelogText,
+		freezeText+changelogText,
 		space,
 		branchText,
 		space,
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
+	"github.com/PraveenGongada/catalyst/internal/styles"
+)
+
+// dispatchTable renders one row per dispatch with its target, size, number
+// of attempts and outcome.
+func dispatchTable(m *MainModel, results []dispatch.Result) string {
+	success := "dispatched"
+	if _, dryRun := m.dryRunDir(); dryRun {
+		success = "written"
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		workflowName := result.Workflow
+		if wf, ok := m.config.GitHub.Workflows[result.Workflow]; ok && wf.Name != "" {
+			workflowName = wf.Name
+		}
+		if part := result.Part(); part != "" {
+			workflowName = fmt.Sprintf("%s (%s)", workflowName, part)
+		}
+
+		outcome := "✔ " + success
+		if result.Error != nil {
+			outcome = "✘ " + result.Error.Error()
+		}
+
+		rows = append(rows, []string{
+			workflowName,
+			fmt.Sprintf("%s@%s", result.Repository, result.Branch),
+			fmt.Sprint(result.Matrices),
+			fmt.Sprint(result.Attempts),
+			outcome,
+		})
+	}
+
+	headers := []string{"Workflow", "Target", "Matrices", "Attempts", "Result"}
+	wrapResults(rows, headers, m.width-4)
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(styles.TableBorderStyle).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return styles.TableHeaderStyle
+			}
+			// lipgloss passes out of range rows while measuring, so check.
+			if col == 4 && row >= 0 && row < len(results) {
+				if results[row].Error != nil {
+					return styles.TableCellStyle.Inherit(styles.RunFailureStyle)
+				}
+				return styles.TableCellStyle.Inherit(styles.RunSuccessStyle)
+			}
+			return styles.TableCellStyle
+		})
+
+	return t.Render()
+}
+
+// wrapResults wraps the last column so the table fits into width. The other
+// columns are short and keep their natural width.
+func wrapResults(rows [][]string, headers []string, width int) {
+	last := len(headers) - 1
+
+	// Each column takes its content, one cell of padding on both sides and a
+	// border on its right; the table also has a left border.
+	used := 1 + 3
+	for col := 0; col < last; col++ {
+		columnWidth := lipgloss.Width(headers[col])
+		for _, row := range rows {
+			columnWidth = max(columnWidth, lipgloss.Width(row[col]))
+		}
+		used += columnWidth + 3
+	}
+
+	available := width - used
+	if available < len(headers[last]) {
+		return
+	}
+
+	for _, row := range rows {
+		if lipgloss.Width(row[last]) > available {
+			row[last] = lipgloss.NewStyle().Width(available).Render(row[last])
+		}
+	}
+}
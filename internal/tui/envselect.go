@@ -89,7 +89,7 @@ func (m *EnvSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if util.HasSelection(m.selections) {
 				m.mainModel.UpdateSelectionsFromModel(types.EnvSelectStage, m.selections)
 				m.mainModel.moveToNextStage()
-				return m.mainModel, nil
+				return m.mainModel, m.mainModel.Init()
 			}
 		case "ctrl+p", "left":
 			m.mainModel.UpdateSelectionsFromModel(types.EnvSelectStage, m.selections)
@@ -22,12 +22,14 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/PraveenGongada/catalyst/internal/github"
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
+	"github.com/PraveenGongada/catalyst/internal/history"
+	"github.com/PraveenGongada/catalyst/internal/notify"
 	"github.com/PraveenGongada/catalyst/internal/types"
 )
 
-/*
-func generateJSON(m *MainModel) tea.Cmd {
+func triggerAction(m *MainModel) tea.Cmd {
 	return func() tea.Msg {
 		confirmModel, ok := m.subModels[types.ConfirmStage].(*ConfirmModel)
 		if !ok {
@@ -42,130 +44,178 @@ func generateJSON(m *MainModel) tea.Cmd {
 
 		inputsModel, ok := m.subModels[types.InputStage].(*InputsModel)
 		var changeLog string
-		var branchName string
+		branchName := "main"
 		if ok {
-			changeLog = inputsModel.changeLog
+			changeLog = strings.TrimSpace(inputsModel.changeLog)
 			branchName = inputsModel.GetBranchName()
 		}
 
-		purifiedMatrices := generator.GroupedMatricesPurified()
-
-		totalMatrices := 0
-		for _, matrices := range purifiedMatrices {
-			totalMatrices += len(matrices)
+		if err := generator.SetBranch(branchName); err != nil {
+			return TriggerMsg{error: err}
 		}
 
-		if totalMatrices == 0 {
-			return TriggerMsg{error: fmt.Errorf("no matrices generated from your selections")}
+		if err := generator.CheckRefs(branchName); err != nil {
+			return TriggerMsg{error: err}
 		}
 
-		timestamp := time.Now().Format("20060102-150405")
-		outputDir := fmt.Sprintf("catalyst-test-payloads-%s", timestamp)
-		if err := os.Mkdir(outputDir, 0755); err != nil {
-			return TriggerMsg{error: fmt.Errorf("failed to create output directory: %w", err)}
+		if err := generator.CheckEnv(); err != nil {
+			return TriggerMsg{error: err}
 		}
 
-		repository := m.config.GitHub.Repository
-		for workflow, matrices := range purifiedMatrices {
-			if len(matrices) == 0 {
-				continue
-			}
+		changeLog = config.FreezeOverrideNote(changeLog, generator.ActiveFreezes(), confirmModel.freezeReason)
 
-			workflowFile := ""
-			if wf, ok := m.config.GitHub.Workflows[workflow]; ok {
-				workflowFile = wf.File
-			} else {
-				workflowFile = workflow + ".yaml"
-			}
+		purifiedMatrices := generator.GroupedMatricesPurified()
 
-			// Create file content structure
-			fileContent := map[string]interface{}{
-				"workflowFile": workflowFile,
-				"branch":       branchName,
-				"matrices":     matrices,
-				"change_log":   changeLog,
-			}
+		if dispatch.TotalMatrices(purifiedMatrices) == 0 {
+			return TriggerMsg{error: fmt.Errorf("no matrices generated from your selections")}
+		}
 
-			payloadBytes, err := json.MarshalIndent(fileContent, "", "  ")
-			if err != nil {
-				return TriggerMsg{error: fmt.Errorf("error marshaling payload: %w", err)}
-			}
+		if err := dispatch.CheckChunks(m.config, purifiedMatrices); err != nil {
+			return TriggerMsg{error: err}
+		}
 
-			filename := filepath.Join(
-				outputDir,
-				fmt.Sprintf("%s-%s.json", workflow, strings.ReplaceAll(repository, "/", "-")),
-			)
-			if err := os.WriteFile(filename, payloadBytes, 0644); err != nil {
-				return TriggerMsg{error: fmt.Errorf("failed to write payload file: %w", err)}
+		// The values of {{env.*}} placeholders may be secrets, so they are
+		// redacted from everything written to disk.
+		redactedMatrices := generator.RedactedMatrices()
+
+		results := dispatch.Run(dispatch.Request{
+			Config:       m.config,
+			Client:       m.client,
+			Matrices:     purifiedMatrices,
+			Branch:       branchName,
+			Placeholders: generator.PlaceholderContext(),
+			ChangeLog:    changeLog,
+			Redacted:     redactedMatrices,
+			Audit:        m.audit,
+		})
+
+		historyID := recordHistory(
+			m, inputsModel, redactedMatrices, branchName, changeLog, results,
+		)
+		notification, notifyErr := notifyDispatch(m, branchName, changeLog, results)
+
+		if failed := dispatch.Failed(results); len(failed) > 0 {
+			return TriggerMsg{
+				results:      results,
+				notification: notification,
+				notifyError:  notifyErr,
+				error:        fmt.Errorf("%d of %d workflow dispatches failed", len(failed), len(results)),
 			}
 		}
 
-		return TriggerMsg{error: nil}
+		return TriggerMsg{
+			results:      results,
+			historyID:    historyID,
+			notification: notification,
+			notifyError:  notifyErr,
+			error:        nil,
+		}
 	}
 }
-*/
 
-func triggerAction(m *MainModel) tea.Cmd {
-	return func() tea.Msg {
-		confirmModel, ok := m.subModels[types.ConfirmStage].(*ConfirmModel)
-		if !ok {
-			return TriggerMsg{error: fmt.Errorf("could not get confirm model")}
-		}
+// notifyDispatch calls the webhooks subscribed to dispatches, unless nothing
+// was dispatched. The message is returned for the completion notification.
+func notifyDispatch(
+	m *MainModel,
+	branchName string,
+	changeLog string,
+	results []dispatch.Result,
+) (*notify.Message, error) {
+	if _, dryRun := m.dryRunDir(); dryRun || len(dispatch.Failed(results)) == len(results) {
+		return nil, nil
+	}
 
-		confirmModel.matrixGenerator.SetSelectedApps(m.GetSelectedApps())
-		confirmModel.matrixGenerator.SetSelectedPlatforms(m.GetSelectedPlatforms())
-		confirmModel.matrixGenerator.SetSelectedEnvironments(m.GetSelectedEnvironments())
+	message := notify.NewMessage(
+		m.config,
+		m.GetSelectedApps(),
+		m.GetSelectedPlatforms(),
+		m.GetSelectedEnvironments(),
+		branchName,
+		changeLog,
+		results,
+	)
+	return &message, notify.Send(m.config, message)
+}
 
-		generator := confirmModel.matrixGenerator
+// notifyCompletion calls the webhooks subscribed to completions with the
+// conclusions of the tracked runs.
+func notifyCompletion(m *MainModel) tea.Cmd {
+	if m.notification == nil || m.runTracking == nil {
+		return nil
+	}
 
-		inputsModel, ok := m.subModels[types.InputStage].(*InputsModel)
-		var changeLog string
-		branchName := "main"
-		if ok {
-			changeLog = strings.TrimSpace(inputsModel.changeLog)
-			branchName = inputsModel.GetBranchName()
+	message := *m.notification
+	message.Event = config.NotificationCompleted
+	message.Workflows = nil
+
+	for _, tracked := range m.runTracking.runs {
+		workflow := notify.Workflow{
+			Name:       tracked.workflowName,
+			Workflow:   tracked.workflow,
+			File:       tracked.file,
+			Repository: tracked.repository,
+			Ref:        tracked.branch,
 		}
-
-		purifiedMatrices := generator.GroupedMatricesPurified()
-
-		totalMatrices := 0
-		for _, matrices := range purifiedMatrices {
-			totalMatrices += len(matrices)
+		switch {
+		case tracked.err != nil:
+			workflow.Status = "unknown"
+			workflow.Error = tracked.err.Error()
+		case tracked.run != nil:
+			workflow.Status = tracked.run.Conclusion
+			workflow.URL = tracked.run.HTMLURL
 		}
+		message.Workflows = append(message.Workflows, workflow)
+	}
 
-		if totalMatrices == 0 {
-			return TriggerMsg{error: fmt.Errorf("no matrices generated from your selections")}
+	// Failed dispatches have no run to track, but still failed the deployment.
+	for _, workflow := range m.notification.Workflows {
+		if workflow.Status == notify.StatusFailed {
+			message.Workflows = append(message.Workflows, workflow)
 		}
+	}
 
-		repository := m.config.GitHub.Repository
-
-		var errors []string
+	cfg := m.config
+	return func() tea.Msg {
+		return notifiedMsg{error: notify.Send(cfg, message)}
+	}
+}
 
-		for workflow, matrices := range purifiedMatrices {
-			if len(matrices) == 0 {
-				continue
-			}
+func recordHistory(
+	m *MainModel,
+	inputsModel *InputsModel,
+	matrices map[string][]map[string]interface{},
+	branchName string,
+	changeLog string,
+	results []dispatch.Result,
+) string {
+	if _, dryRun := m.dryRunDir(); dryRun {
+		return ""
+	}
 
-			if wf, ok := m.config.GitHub.Workflows[workflow]; ok {
-				err := github.TriggerWorkflow(
-					repository,
-					wf.File,
-					matrices,
-					changeLog,
-					branchName,
-				)
-				if err != nil {
-					errors = append(errors, fmt.Sprintf("'%s': %v", workflow, err))
-				}
-			} else {
-				errors = append(errors, fmt.Sprintf("workflow '%s' not found in configuration", workflow))
-			}
-		}
+	if m.history == nil || len(dispatch.Failed(results)) == len(results) {
+		return ""
+	}
 
-		if len(errors) > 0 {
-			return TriggerMsg{error: fmt.Errorf("workflow trigger errors: %s", strings.Join(errors, "; "))}
-		}
+	var inputs map[string]string
+	if inputsModel != nil {
+		inputs = inputsModel.GetInputValues()
+	}
 
-		return TriggerMsg{error: nil}
+	entry := history.NewEntry(
+		m.config.GitHub.Repository,
+		m.GetSelectedApps(),
+		m.GetSelectedPlatforms(),
+		m.GetSelectedEnvironments(),
+		inputs,
+		branchName,
+		changeLog,
+		matrices,
+		results,
+	)
+
+	// History is best effort and must never fail a dispatch that already happened.
+	if err := m.history.Save(entry); err != nil {
+		return ""
 	}
+	return entry.ID
 }
@@ -18,14 +18,20 @@ package tui
 
 import (
 	"errors"
-	"regexp"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 
-	"github.com/PraveenGongada/catalyst/internal/constants"
+	"github.com/PraveenGongada/catalyst/internal/changelog"
+	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/matrix"
+	"github.com/PraveenGongada/catalyst/internal/refs"
 	"github.com/PraveenGongada/catalyst/internal/styles"
+	"github.com/PraveenGongada/catalyst/internal/types"
 )
 
 type InputsModel struct {
@@ -33,19 +39,135 @@ type InputsModel struct {
 	form            *huh.Form
 	inputs          map[string]string
 	changeLog       string
+	changeLogError  error
+	prefilled       bool
+	generating      bool
 	branchName      string
 	tempInputValues []tempInput
+
+	// refs are the branches and tags of refsRepositories, which the branch
+	// name is checked against once loaded.
+	refs             refs.Targets
+	refsRepositories []string
+	refsLoaded       bool
+}
+
+// changeLogMsg delivers a changelog generated in the background. A prefill
+// does not replace a changelog entered in the meantime.
+type changeLogMsg struct {
+	changeLog string
+	error     error
+	prefill   bool
+}
+
+type refsMsg struct {
+	repositories []string
+	refs         refs.Targets
 }
 
 type tempInput struct {
-	key   string
-	value string
+	key       string
+	value     string
+	boolValue bool
 }
 
 func (m *InputsModel) Init() tea.Cmd {
+	// A changelog still being generated was lost if the stage was left.
+	m.generating = false
+
+	var cmds []tea.Cmd
+	if m.mainModel.config.Changelog.Prefill && !m.prefilled && m.changeLog == "" {
+		cmds = append(cmds, m.generateChangeLog(true))
+	}
+
+	m.mainModel.applyInputSources(m)
 	m.initTempValues()
 	m.form = createInputForms(m)
-	return m.form.Init()
+	cmds = append(cmds, m.form.Init())
+
+	// The selection may have changed since the refs were loaded.
+	repositories := m.generator().BranchRepositories()
+	if !m.refsLoaded || !slices.Equal(repositories, m.refsRepositories) {
+		m.refsRepositories = repositories
+		m.refsLoaded = false
+		cmds = append(cmds, m.loadRefs(repositories))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (m *InputsModel) loadRefs(repositories []string) tea.Cmd {
+	cfg, client := m.mainModel.config, m.mainModel.client
+	return func() tea.Msg {
+		return refsMsg{repositories: repositories, refs: refs.LoadTargets(cfg, client, repositories)}
+	}
+}
+
+func (m *InputsModel) generator() *matrix.Generator {
+	generator := matrix.NewGenerator(m.mainModel.config)
+	generator.SetSelectedApps(m.mainModel.GetSelectedApps())
+	generator.SetSelectedPlatforms(m.mainModel.GetSelectedPlatforms())
+	generator.SetSelectedEnvironments(m.mainModel.GetSelectedEnvironments())
+	return generator
+}
+
+// validateBranch checks that every selected environment may be deployed
+// from the branch and, once the refs are loaded, that the branch exists in
+// the repositories it is dispatched to. Refs that are not conclusive only
+// produce warnings in the description.
+func (m *InputsModel) validateBranch(branch string) error {
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		return errors.New("branch name is required")
+	}
+
+	if disallowed := m.generator().DisallowedSelections(branch); len(disallowed) > 0 {
+		return fmt.Errorf("not allowed: %s", strings.Join(disallowed, "; "))
+	}
+
+	if !m.refsLoaded {
+		return nil
+	}
+	_, err := m.refs.Check(branch)
+	return err
+}
+
+func (m *InputsModel) branchDescription() string {
+	description := "Please enter the branch name to trigger workflows on"
+
+	if !m.refsLoaded {
+		return description + " (loading branches…)"
+	}
+
+	merged := m.refs.Merged()
+	description += fmt.Sprintf(" (tab: complete from %d refs)", len(merged.Names))
+
+	branch := m.GetBranchName()
+	if branch == "" {
+		return description
+	}
+	if warnings, _ := m.refs.Check(branch); len(warnings) > 0 {
+		description += "\nCould not verify the branch: " + strings.Join(warnings, "; ")
+	}
+	if !merged.Contains(branch) {
+		if closest := merged.Closest(branch, 5); len(closest) > 0 {
+			description += "\nClosest matches: " + strings.Join(closest, ", ")
+		}
+	}
+	return description
+}
+
+// generateChangeLog returns a command generating the changelog from the
+// git history in the background, which can take a while in large
+// repositories.
+func (m *InputsModel) generateChangeLog(prefill bool) tea.Cmd {
+	m.generating = true
+	opts := changelog.OptionsFor(m.mainModel.config, m.mainModel.GetSelectedApps(), "", m.GetBranchName())
+
+	return func() tea.Msg {
+		changeLog, err := changelog.Generate(opts)
+		return changeLogMsg{changeLog: changeLog, error: err, prefill: prefill}
+	}
 }
 
 func (m *InputsModel) initTempValues() {
@@ -58,15 +180,27 @@ func (m *InputsModel) initTempValues() {
 			value = m.mainModel.config.Inputs[key].Default
 			m.inputs[key] = value
 		}
+		inputConfig := m.mainModel.config.Inputs[key]
+		if value == "" && inputConfig.InputType() == config.InputTypeChoice &&
+			len(inputConfig.Options) > 0 {
+			value = inputConfig.Options[0]
+		}
+
+		boolValue, _ := strconv.ParseBool(value)
 		m.tempInputValues = append(m.tempInputValues, tempInput{
-			key:   key,
-			value: value,
+			key:       key,
+			value:     value,
+			boolValue: boolValue,
 		})
 	}
 }
 
 func (m *InputsModel) updateInputsFromTemp() {
 	for _, temp := range m.tempInputValues {
+		if m.mainModel.config.Inputs[temp.key].InputType() == config.InputTypeBoolean {
+			m.inputs[temp.key] = strconv.FormatBool(temp.boolValue)
+			continue
+		}
 		m.inputs[temp.key] = temp.value
 	}
 }
@@ -77,6 +211,27 @@ func (m *InputsModel) View() string {
 
 func (m *InputsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case changeLogMsg:
+		m.generating = false
+		m.changeLogError = msg.error
+		if msg.prefill {
+			m.prefilled = true
+		}
+		if msg.error == nil && (!msg.prefill || strings.TrimSpace(m.changeLog) == "") {
+			m.changeLog = msg.changeLog
+		}
+
+		m.updateInputsFromTemp()
+		m.initTempValues()
+		m.form = createInputForms(m)
+		return m, m.form.Init()
+
+	case refsMsg:
+		if slices.Equal(msg.repositories, m.refsRepositories) {
+			m.refs = msg.refs
+			m.refsLoaded = true
+		}
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
@@ -92,6 +247,12 @@ func (m *InputsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+p":
 			m.mainModel.moveToPreviousStage()
 			return m.mainModel, nil
+		case "ctrl+g":
+			m.updateInputsFromTemp()
+			cmd := m.generateChangeLog(false)
+			m.initTempValues()
+			m.form = createInputForms(m)
+			return m, tea.Batch(cmd, m.form.Init())
 		}
 	}
 
@@ -123,13 +284,26 @@ func NewInputsModel(m *MainModel) *InputsModel {
 		inputs.inputs[key] = input.Default
 	}
 
+	// Keep the values entered so far when the models are rebuilt on resize.
+	if previous, ok := m.subModels[types.InputStage].(*InputsModel); ok {
+		for key, value := range previous.inputs {
+			inputs.inputs[key] = value
+		}
+		inputs.changeLog = previous.changeLog
+		inputs.changeLogError = previous.changeLogError
+		inputs.prefilled = previous.prefilled
+		inputs.generating = previous.generating
+		inputs.branchName = previous.branchName
+		inputs.refs = previous.refs
+		inputs.refsRepositories = previous.refsRepositories
+		inputs.refsLoaded = previous.refsLoaded
+	}
+
 	inputs.initTempValues()
 
 	return inputs
 }
 
-var inputRefPattern = regexp.MustCompile(constants.RegexInputPlaceholder)
-
 func getRelevantInputs(m *MainModel) []string {
 	inputsNeeded := make(map[string]bool)
 
@@ -145,15 +319,16 @@ func getRelevantInputs(m *MainModel) []string {
 						if envConfig, ok := platformConfig[env]; ok {
 							for _, value := range envConfig.Matrix {
 								if strValue, ok := value.(string); ok {
-									matches := inputRefPattern.FindAllStringSubmatch(strValue, -1)
-									for _, match := range matches {
-										if len(match) >= 2 {
-											inputName := match[1]
-											inputsNeeded[inputName] = true
-										}
+									for _, inputName := range config.InputReferences(strValue) {
+										inputsNeeded[inputName] = true
 									}
 								}
 							}
+
+							workflow := m.config.GitHub.Workflows[envConfig.Workflow]
+							for _, inputName := range workflow.InputReferences() {
+								inputsNeeded[inputName] = true
+							}
 						}
 					}
 				}
@@ -181,34 +356,63 @@ func createInputForms(inputs *InputsModel) *huh.Form {
 		key := temp.key
 		inputConfig := m.config.Inputs[key]
 
-		field := huh.NewInput().
-			Title(key + ": ").
-			Description(inputConfig.Description).
-			Value(&inputs.tempInputValues[i].value).
-			Validate(func(s string) error {
-				if inputConfig.Required && strings.TrimSpace(s) == "" {
-					return errors.New(key + " is required")
-				}
-				return nil
-			})
+		if err, ok := m.sourceErrors[key]; ok {
+			inputConfig.Description = strings.TrimSpace(fmt.Sprintf(
+				"%s (could not compute from %s: %v)",
+				inputConfig.Description, inputConfig.Source.Type, err,
+			))
+		}
+
+		var field huh.Field
+		switch inputConfig.InputType() {
+		case config.InputTypeBoolean:
+			field = huh.NewConfirm().
+				Title(key + ": ").
+				Description(inputConfig.Description).
+				Value(&inputs.tempInputValues[i].boolValue)
+
+		case config.InputTypeChoice:
+			field = huh.NewSelect[string]().
+				Title(key + ": ").
+				Description(inputConfig.Description).
+				Options(huh.NewOptions(inputConfig.Options...)...).
+				Value(&inputs.tempInputValues[i].value)
+
+		default:
+			field = huh.NewInput().
+				Title(key + ": ").
+				Description(inputConfig.Description).
+				Value(&inputs.tempInputValues[i].value).
+				Validate(func(s string) error {
+					if err := inputConfig.ValidateValue(s); err != nil {
+						return errors.New(key + " " + err.Error())
+					}
+					return nil
+				})
+		}
 
 		inputFields = append(inputFields, field)
 	}
 
 	branchField := huh.NewInput().
 		Value(&inputs.branchName).
-		Validate(func(s string) error {
-			if strings.TrimSpace(s) == "" {
-				return errors.New("branch name is required")
-			}
-			return nil
-		}).
+		Validate(inputs.validateBranch).
 		Title("Branch Name: ").
-		Description("Please enter the branch name to trigger workflows on").
+		DescriptionFunc(inputs.branchDescription, []interface{}{&inputs.branchName, &inputs.refsLoaded}).
+		SuggestionsFunc(func() []string { return inputs.refs.Merged().Names }, &inputs.refsLoaded).
 		Placeholder("main")
 
 	inputFields = append(inputFields, branchField)
 
+	changelogDescription := "Please add the changes in this release (ctrl+g: generate from git history)"
+	switch {
+	case inputs.generating:
+		changelogDescription += "\nGenerating changelog from git history…"
+	case inputs.changeLogError != nil:
+		changelogDescription = fmt.Sprintf("%s\nCould not generate changelog: %v",
+			changelogDescription, inputs.changeLogError)
+	}
+
 	changelogField := huh.NewText().
 		Value(&inputs.changeLog).
 		Validate(func(s string) error {
@@ -218,8 +422,8 @@ func createInputForms(inputs *InputsModel) *huh.Form {
 			return nil
 		}).
 		Title("Changelog: ").
-		Description("Please add the changes in this release").
-		CharLimit(400).
+		Description(changelogDescription).
+		CharLimit(changelog.MaxLength(m.config)).
 		Lines(5)
 
 	inputFields = append(inputFields, changelogField)
@@ -236,12 +440,12 @@ func (m *InputsModel) hasValidInputs() bool {
 
 	for key, value := range m.inputs {
 		inputConfig := m.mainModel.config.Inputs[key]
-		if inputConfig.Required && strings.TrimSpace(value) == "" {
+		if err := inputConfig.ValidateValue(value); err != nil {
 			return false
 		}
 	}
 
-	return strings.TrimSpace(m.changeLog) != "" && strings.TrimSpace(m.branchName) != ""
+	return strings.TrimSpace(m.changeLog) != "" && m.validateBranch(m.branchName) == nil
 }
 
 func (m *InputsModel) GetInputValues() map[string]string {
@@ -252,6 +456,21 @@ func (m *InputsModel) GetInputValues() map[string]string {
 	return trimmedInputs
 }
 
+func (m *InputsModel) SetValues(inputs map[string]string, branchName, changeLog string) {
+	for key, value := range inputs {
+		if _, ok := m.mainModel.config.Inputs[key]; ok {
+			m.inputs[key] = value
+		}
+	}
+	if branchName != "" {
+		m.branchName = branchName
+	}
+	if changeLog != "" {
+		m.changeLog = changeLog
+	}
+	m.initTempValues()
+}
+
 func (m *InputsModel) GetBranchName() string {
 	return strings.TrimSpace(m.branchName)
 }
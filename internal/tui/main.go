@@ -18,11 +18,17 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/PraveenGongada/catalyst/internal/audit"
 	"github.com/PraveenGongada/catalyst/internal/config"
+	"github.com/PraveenGongada/catalyst/internal/dispatch"
 	"github.com/PraveenGongada/catalyst/internal/github"
+	"github.com/PraveenGongada/catalyst/internal/history"
+	"github.com/PraveenGongada/catalyst/internal/inputsource"
+	"github.com/PraveenGongada/catalyst/internal/notify"
 	"github.com/PraveenGongada/catalyst/internal/styles"
 	"github.com/PraveenGongada/catalyst/internal/types"
 )
@@ -33,22 +39,54 @@ type MainModel struct {
 	width        int
 	height       int
 	config       *config.Config
+	client       github.Client
 
+	selectedPreset       string
 	selectedApps         []string
 	selectedPlatforms    []string
 	selectedEnvironments []string
+
+	dispatchResults []dispatch.Result
+	runTracking     *runTracking
+
+	notification       *notify.Message
+	notifyError        error
+	completionNotified bool
+
+	history   *history.Store
+	historyID string
+	audit     *audit.Log
+
+	// sourced are the values computed for the inputs with a source for
+	// sourcedApps and sourcedPlatforms.
+	sourced          map[string]string
+	sourceErrors     map[string]error
+	sourcedApps      []string
+	sourcedPlatforms []string
+	sourcesComputed  bool
 }
 
-func NewMainModel(cfg *config.Config) *MainModel {
+type Options struct {
+	HistoryID string
+	Preset    string
+	DryRun    bool
+	OutputDir string
+}
+
+func NewMainModel(cfg *config.Config, client github.Client) *MainModel {
 	model := &MainModel{
 		currentStage:         types.AppSelectStage,
 		height:               styles.DefaultHeight,
 		width:                styles.DefaultWidth,
 		config:               cfg,
+		client:               client,
 		selectedApps:         []string{},
 		selectedPlatforms:    []string{},
 		selectedEnvironments: []string{},
 	}
+	if len(cfg.Presets) > 0 {
+		model.currentStage = types.PresetSelectStage
+	}
 	model.subModels = getAllModels(model)
 	return model
 }
@@ -85,6 +123,9 @@ func (m *MainModel) moveToNextStage() {
 }
 
 func (m *MainModel) moveToPreviousStage() {
+	if m.currentStage == types.AppSelectStage && len(m.config.Presets) == 0 {
+		return
+	}
 	if int(m.currentStage) > 0 {
 		m.currentStage--
 		model := m.subModels[m.currentStage]
@@ -139,19 +180,112 @@ func (m *MainModel) UpdateSelectionsFromModel(
 
 func getAllModels(m *MainModel) map[types.Stage]tea.Model {
 	return map[types.Stage]tea.Model{
-		types.AppSelectStage: NewAppSelectModel(m),
-		types.OSSelectStage:  NewOSSelectModel(m),
-		types.EnvSelectStage: NewEnvSelectModel(m),
-		types.InputStage:     NewInputsModel(m),
-		types.ConfirmStage:   NewConfirmModel(m),
+		types.PresetSelectStage: NewPresetSelectModel(m),
+		types.AppSelectStage:    NewAppSelectModel(m),
+		types.OSSelectStage:     NewOSSelectModel(m),
+		types.EnvSelectStage:    NewEnvSelectModel(m),
+		types.InputStage:        NewInputsModel(m),
+		types.ConfirmStage:      NewConfirmModel(m),
+		types.GitActionStage:    NewRunsModel(m),
+	}
+}
+
+func (m *MainModel) dryRunDir() (string, bool) {
+	if client, ok := m.client.(*github.DryRunClient); ok {
+		return client.OutputDir, true
+	}
+	return "", false
+}
+
+// applyInputSources computes the inputs with a source for the selected apps
+// and platforms, unless they were computed for them already. Values entered
+// or taken from a preset are kept; only defaults and the values computed for
+// a previous selection are replaced.
+func (m *MainModel) applyInputSources(inputsModel *InputsModel) {
+	if m.sourcesComputed && slices.Equal(m.sourcedApps, m.selectedApps) &&
+		slices.Equal(m.sourcedPlatforms, m.selectedPlatforms) {
+		return
+	}
+
+	resolver := inputsource.NewResolver(m.config, m.history, m.selectedApps, m.selectedPlatforms)
+	values, errors := resolver.ResolveAll()
+
+	for key, input := range m.config.Inputs {
+		replaceable := input.Default
+		if previous, ok := m.sourced[key]; ok {
+			replaceable = previous
+		}
+		if inputsModel.inputs[key] != replaceable {
+			continue
+		}
+
+		if value, ok := values[key]; ok {
+			inputsModel.inputs[key] = value
+		} else {
+			inputsModel.inputs[key] = input.Default
+		}
+	}
+
+	m.sourced = values
+	m.sourceErrors = errors
+	m.sourcedApps = m.selectedApps
+	m.sourcedPlatforms = m.selectedPlatforms
+	m.sourcesComputed = true
+}
+
+func (m *MainModel) applyPreset(name string) error {
+	previous := m.selectedPreset
+	m.selectedPreset = name
+	if name == "" {
+		if previous != "" {
+			m.selectedApps = []string{}
+			m.selectedPlatforms = []string{}
+			m.selectedEnvironments = []string{}
+		}
+		return nil
+	}
+
+	preset, err := m.config.ResolvePreset(name)
+	if err != nil {
+		return err
 	}
+
+	m.selectedApps = preset.Apps
+	m.selectedPlatforms = preset.Platforms
+	m.selectedEnvironments = preset.Environments
+
+	if inputsModel, ok := m.subModels[types.InputStage].(*InputsModel); ok {
+		inputsModel.SetValues(preset.Inputs, "", "")
+	}
+
+	return nil
 }
 
-func Start(configPath string) error {
-	if err := github.IsGHInstalled(); err != nil {
-		return fmt.Errorf("error: %w", err)
+// preloadHistory selects the apps, platforms and environments of a previous
+// deployment and fills in its inputs, branch and changelog, for them to be
+// validated and reviewed like any other deployment. Inputs with a source
+// keep the value computed for this deployment, e.g. the next build number.
+func (m *MainModel) preloadHistory(entry *history.Entry) {
+	m.selectedApps = entry.Apps
+	m.selectedPlatforms = entry.Platforms
+	m.selectedEnvironments = entry.Environments
+	m.subModels = getAllModels(m)
+
+	inputs := make(map[string]string)
+	for key, value := range entry.Inputs {
+		if m.config.Inputs[key].Source.Type == "" {
+			inputs[key] = value
+		}
+	}
+
+	if inputsModel, ok := m.subModels[types.InputStage].(*InputsModel); ok {
+		inputsModel.SetValues(inputs, entry.Branch, entry.ChangeLog)
 	}
 
+	m.currentStage = types.InputStage
+}
+
+func Start(configPath string, opts Options) error {
 	cfg, err := config.Load(configPath)
 	if err != nil {
 		return fmt.Errorf("error loading configuration: %w", err)
@@ -161,7 +295,48 @@ func Start(configPath string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	mainModel := NewMainModel(cfg)
+	var client github.Client
+	if opts.DryRun {
+		if opts.OutputDir == github.StdoutOutput {
+			return fmt.Errorf("dry-run output to stdout is only supported by the trigger command")
+		}
+		client = github.NewDryRunClient(opts.OutputDir)
+	} else {
+		client, err = github.NewClient(cfg.GitHub)
+		if err != nil {
+			return fmt.Errorf("error: %w", err)
+		}
+	}
+
+	mainModel := NewMainModel(cfg, client)
+
+	store, err := history.DefaultStore(cfg.GitHub.Repository)
+	if err == nil {
+		mainModel.history = store
+	}
+
+	// Dry runs dispatch nothing, so there is nothing to audit.
+	if !opts.DryRun {
+		mainModel.audit, _ = audit.Open(cfg)
+	}
+
+	if opts.HistoryID != "" {
+		if store == nil {
+			return fmt.Errorf("error loading deployment history: %w", err)
+		}
+
+		entry, err := store.Get(opts.HistoryID)
+		if err != nil {
+			return fmt.Errorf("error loading deployment history: %w", err)
+		}
+		mainModel.preloadHistory(entry)
+	} else if opts.Preset != "" {
+		if err := mainModel.applyPreset(opts.Preset); err != nil {
+			return fmt.Errorf("error applying preset: %w", err)
+		}
+		mainModel.currentStage = types.AppSelectStage
+	}
+
 	_, err = tea.NewProgram(mainModel, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
 	return err
 }
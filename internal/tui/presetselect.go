@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/PraveenGongada/catalyst/internal/styles"
+	"github.com/PraveenGongada/catalyst/internal/types"
+)
+
+const noPresetTitle = "Start without a preset"
+
+func getPresetSelections(m *MainModel) ([]types.SelectableItem, []string) {
+	presets := m.config.GetPresets()
+
+	items := make([]types.SelectableItem, 0, len(presets)+1)
+	names := make([]string, 0, len(presets)+1)
+
+	items = append(items, types.SelectableItem{
+		Title:    noPresetTitle,
+		Selected: m.selectedPreset == "",
+	})
+	names = append(names, "")
+
+	for _, preset := range presets {
+		title := preset
+		if description := m.config.Presets[preset].Description; description != "" {
+			title = fmt.Sprintf("%s - %s", preset, description)
+		}
+
+		items = append(items, types.SelectableItem{
+			Title:    title,
+			Selected: m.selectedPreset == preset,
+		})
+		names = append(names, preset)
+	}
+
+	return items, names
+}
+
+type PresetSelectModel struct {
+	selections []types.SelectableItem
+	names      []string
+	list       list.Model
+	mainModel  *MainModel
+}
+
+func NewPresetSelectModel(m *MainModel) *PresetSelectModel {
+	selections, names := getPresetSelections(m)
+	presetSelectionList := createPresetSelectionList(m, &selections)
+	return &PresetSelectModel{
+		selections: selections,
+		names:      names,
+		list:       presetSelectionList,
+		mainModel:  m,
+	}
+}
+
+func (m *PresetSelectModel) Init() tea.Cmd {
+	m.selections, m.names = getPresetSelections(m.mainModel)
+	m.list = createPresetSelectionList(m.mainModel, &m.selections)
+	return nil
+}
+
+func (m *PresetSelectModel) View() string {
+	return styles.AppStyle.Render(m.list.View())
+}
+
+func (m *PresetSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch keypress := msg.String(); keypress {
+		case "ctrl+c":
+			return m, tea.Interrupt
+		case "esc", "q":
+			return m, tea.Quit
+		case " ", "right", "enter", "ctrl+n":
+			index := m.list.Index()
+			if index < 0 || index >= len(m.names) {
+				return m, nil
+			}
+
+			if err := m.mainModel.applyPreset(m.names[index]); err != nil {
+				return m, m.list.NewStatusMessage(styles.GitHubErrorStyle.Render(err.Error()))
+			}
+			m.mainModel.moveToNextStage()
+			return m.mainModel, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func createPresetSelectionList(m *MainModel, selections *[]types.SelectableItem) list.Model {
+	items := make([]list.Item, len(*selections))
+	for i, item := range *selections {
+		items[i] = item
+	}
+	l := list.New(items, types.ItemDelegate{}, m.width, m.height)
+	l.Title = "Start from a preset"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("right", "enter", "ctrl+n"),
+				key.WithHelp("→/ctrl+n/enter", "use preset"),
+			),
+		}
+	}
+	l.Styles.Title = styles.TitleStyle
+	l.Styles.PaginationStyle = styles.PaginationStyle
+	l.Styles.HelpStyle = styles.HelpStyle
+
+	return l
+}
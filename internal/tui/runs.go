@@ -0,0 +1,433 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/PraveenGongada/catalyst/internal/github"
+	"github.com/PraveenGongada/catalyst/internal/styles"
+)
+
+const (
+	runPollInterval  = 5 * time.Second
+	runLocateTimeout = 2 * time.Minute
+)
+
+type trackedRun struct {
+	workflow     string
+	workflowName string
+	file         string
+	repository   string
+	branch       string
+	dispatchedAt time.Time
+	run          *github.WorkflowRun
+	jobs         []github.Job
+	err          error
+}
+
+func (t trackedRun) done() bool {
+	return t.err != nil || (t.run != nil && t.run.IsCompleted())
+}
+
+type runTracking struct {
+	actor         string
+	actorResolved bool
+	runs          []trackedRun
+}
+
+func (t *runTracking) done() bool {
+	for _, run := range t.runs {
+		if !run.done() {
+			return false
+		}
+	}
+	return true
+}
+
+type runsPollMsg struct{}
+
+type runsUpdatedMsg struct {
+	tracking *runTracking
+}
+
+type notifiedMsg struct {
+	error error
+}
+
+type RunsModel struct {
+	mainModel *MainModel
+	viewport  viewport.Model
+	spinner   spinner.Model
+	polling   bool
+}
+
+func NewRunsModel(m *MainModel) *RunsModel {
+	vp := viewport.New(m.width, m.height-1)
+	vp.HighPerformanceRendering = false
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+
+	return &RunsModel{
+		mainModel: m,
+		viewport:  vp,
+		spinner:   s,
+	}
+}
+
+func newRunTracking(m *MainModel) *runTracking {
+	tracking := &runTracking{}
+
+	for _, result := range m.dispatchResults {
+		if result.Error != nil {
+			continue
+		}
+
+		workflowName := result.Workflow
+		if wf, ok := m.config.GitHub.Workflows[result.Workflow]; ok && wf.Name != "" {
+			workflowName = wf.Name
+		}
+		if part := result.Part(); part != "" {
+			workflowName = fmt.Sprintf("%s (%s)", workflowName, part)
+		}
+
+		tracking.runs = append(tracking.runs, trackedRun{
+			workflow:     result.Workflow,
+			workflowName: workflowName,
+			file:         result.File,
+			repository:   result.Repository,
+			branch:       result.Branch,
+			dispatchedAt: result.DispatchedAt,
+		})
+	}
+
+	return tracking
+}
+
+func (m *RunsModel) Init() tea.Cmd {
+	if m.mainModel.runTracking == nil {
+		m.mainModel.runTracking = newRunTracking(m.mainModel)
+	}
+
+	m.viewport.SetContent(m.runsContent())
+
+	if m.polling || m.mainModel.runTracking.done() {
+		return nil
+	}
+	m.polling = true
+
+	return tea.Batch(m.spinner.Tick, refreshRuns(m.mainModel, m.mainModel.runTracking))
+}
+
+func (m *RunsModel) View() string {
+	helpText := styles.CustomHelpStyle.Render("↑/k: scroll up • ↓/j: scroll down • q/esc: quit")
+
+	tracking := m.mainModel.runTracking
+	if tracking != nil && !tracking.done() {
+		return m.viewport.View() + "\n\n" + styles.GitHubMessageStyle.Render(
+			fmt.Sprintf("Tracking workflow runs %s", m.spinner.View()),
+		) + "\n" + helpText
+	}
+
+	return m.viewport.View() + "\n\n" + styles.GitHubMessageStyle.Render(
+		"All tracked workflow runs have finished.",
+	) + "\n" + helpText
+}
+
+func (m *RunsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = msg.Height - 4
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Interrupt
+		case "esc", "q":
+			return m, tea.Quit
+		}
+
+	case runsPollMsg:
+		return m, refreshRuns(m.mainModel, m.mainModel.runTracking)
+
+	case runsUpdatedMsg:
+		m.mainModel.runTracking = msg.tracking
+		m.viewport.SetContent(m.runsContent())
+
+		if msg.tracking.done() {
+			m.polling = false
+			if m.mainModel.completionNotified {
+				return m, nil
+			}
+			m.mainModel.completionNotified = true
+			return m, notifyCompletion(m.mainModel)
+		}
+
+		pollCmd := tea.Tick(runPollInterval, func(time.Time) tea.Msg {
+			return runsPollMsg{}
+		})
+
+		// The model is rebuilt on resize, so restart the spinner if needed.
+		if !m.polling {
+			m.polling = true
+			return m, tea.Batch(m.spinner.Tick, pollCmd)
+		}
+		return m, pollCmd
+
+	case notifiedMsg:
+		if msg.error != nil {
+			m.mainModel.notifyError = msg.error
+			m.viewport.SetContent(m.runsContent())
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		var spinnerCmd tea.Cmd
+		m.spinner, spinnerCmd = m.spinner.Update(msg)
+		cmds = append(cmds, spinnerCmd)
+		m.viewport.SetContent(m.runsContent())
+	}
+
+	var viewportCmd tea.Cmd
+	m.viewport, viewportCmd = m.viewport.Update(msg)
+	cmds = append(cmds, viewportCmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func refreshRuns(m *MainModel, current *runTracking) tea.Cmd {
+	client := m.client
+	store := m.history
+	historyID := m.historyID
+
+	tracking := &runTracking{
+		actor:         current.actor,
+		actorResolved: current.actorResolved,
+		runs:          make([]trackedRun, len(current.runs)),
+	}
+	copy(tracking.runs, current.runs)
+
+	return func() tea.Msg {
+		if !tracking.actorResolved {
+			// Without an actor, runs from other users may be matched.
+			if actor, err := github.CurrentUser(client); err == nil {
+				tracking.actor = actor
+			}
+			tracking.actorResolved = true
+		}
+
+		claimed := make(map[int64]bool)
+		for _, tracked := range tracking.runs {
+			if tracked.run != nil {
+				claimed[tracked.run.ID] = true
+			}
+		}
+
+		for i := range tracking.runs {
+			tracked := &tracking.runs[i]
+			if tracked.done() {
+				continue
+			}
+
+			if tracked.run == nil {
+				locateRun(client, tracking.actor, tracked, claimed)
+				if tracked.run != nil && store != nil && historyID != "" {
+					_ = store.AddRunID(historyID, tracked.workflow, tracked.run.ID)
+				}
+				continue
+			}
+
+			run, err := github.GetWorkflowRun(client, tracked.repository, tracked.run.ID)
+			if err != nil {
+				continue
+			}
+			tracked.run = &run
+
+			if jobs, err := github.ListRunJobs(client, tracked.repository, run.ID); err == nil {
+				tracked.jobs = jobs
+			}
+		}
+
+		return runsUpdatedMsg{tracking: tracking}
+	}
+}
+
+func locateRun(client github.Client, actor string, tracked *trackedRun, claimed map[int64]bool) {
+	runs, err := github.FindWorkflowRuns(
+		client,
+		tracked.repository,
+		tracked.file,
+		tracked.branch,
+		actor,
+		tracked.dispatchedAt,
+	)
+	if err == nil {
+		for _, run := range runs {
+			if claimed[run.ID] {
+				continue
+			}
+			claimed[run.ID] = true
+			tracked.run = &run
+			return
+		}
+	}
+
+	if time.Since(tracked.dispatchedAt) > runLocateTimeout {
+		if err == nil {
+			err = fmt.Errorf("no run found within %s of dispatch", runLocateTimeout)
+		}
+		tracked.err = err
+	}
+}
+
+func (m *RunsModel) runsContent() string {
+	divider := styles.SummaryDividerStyle.Render(strings.Repeat("━", 55))
+	header := styles.SummaryHeaderStyle.Render("🛰  GitHub Action Runs")
+
+	tracking := m.mainModel.runTracking
+	if tracking == nil || len(tracking.runs) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			divider,
+			"",
+			header,
+			"",
+			divider,
+			"",
+			"No dispatched workflows to track.",
+		)
+	}
+
+	var content strings.Builder
+	now := time.Now()
+
+	for _, tracked := range tracking.runs {
+		content.WriteString(styles.SummaryTitleStyle.Render(tracked.workflowName))
+		content.WriteString(styles.RunMutedStyle.Render(fmt.Sprintf("  (%s)", tracked.file)))
+		content.WriteString("\n")
+
+		switch {
+		case tracked.err != nil:
+			content.WriteString("   " + styles.RunFailureStyle.Render("✘ "+tracked.err.Error()))
+			content.WriteString("\n")
+
+		case tracked.run == nil:
+			content.WriteString("   " + styles.RunPendingStyle.Render(
+				fmt.Sprintf("%s waiting for run to appear", m.spinner.View()),
+			))
+			content.WriteString("\n")
+
+		default:
+			run := tracked.run
+			content.WriteString(fmt.Sprintf("   %s  %s  %s\n",
+				m.runStatus(*run),
+				jobProgress(tracked.jobs),
+				styles.RunMutedStyle.Render(formatElapsed(run.Elapsed(now))),
+			))
+
+			for _, job := range tracked.jobs {
+				content.WriteString(fmt.Sprintf("     %s %s\n", m.jobIcon(job), job.Name))
+			}
+
+			if run.HTMLURL != "" {
+				content.WriteString("   " + styles.RunMutedStyle.Render(run.HTMLURL) + "\n")
+			}
+		}
+
+		content.WriteString("\n")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		divider,
+		"",
+		header,
+		"",
+		divider,
+		"",
+		dispatchTable(m.mainModel, m.mainModel.dispatchResults),
+		"",
+		content.String(),
+		m.notifyWarning(),
+	)
+}
+
+func (m *RunsModel) notifyWarning() string {
+	if m.mainModel.notifyError == nil {
+		return ""
+	}
+	return styles.RunFailureStyle.Render("⚠ Notifications failed: " + m.mainModel.notifyError.Error())
+}
+
+func (m *RunsModel) runStatus(run github.WorkflowRun) string {
+	if !run.IsCompleted() {
+		return styles.RunPendingStyle.Render(
+			fmt.Sprintf("%s %s", m.spinner.View(), strings.ReplaceAll(run.Status, "_", " ")),
+		)
+	}
+
+	if run.Conclusion == "success" {
+		return styles.RunSuccessStyle.Render("✔ success")
+	}
+	return styles.RunFailureStyle.Render("✘ " + strings.ReplaceAll(run.Conclusion, "_", " "))
+}
+
+func (m *RunsModel) jobIcon(job github.Job) string {
+	switch {
+	case job.Status != github.RunStatusCompleted:
+		return styles.RunPendingStyle.Render("•")
+	case job.Conclusion == "success":
+		return styles.RunSuccessStyle.Render("✔")
+	case job.Conclusion == "skipped":
+		return styles.RunMutedStyle.Render("-")
+	default:
+		return styles.RunFailureStyle.Render("✘")
+	}
+}
+
+func jobProgress(jobs []github.Job) string {
+	if len(jobs) == 0 {
+		return styles.RunMutedStyle.Render("no jobs yet")
+	}
+
+	completed := 0
+	for _, job := range jobs {
+		if job.Status == github.RunStatusCompleted {
+			completed++
+		}
+	}
+
+	return fmt.Sprintf("%d/%d jobs done", completed, len(jobs))
+}
+
+func formatElapsed(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+	return d.Truncate(time.Second).String()
+}
@@ -30,7 +30,8 @@ import (
 type Stage int
 
 const (
-	AppSelectStage Stage = iota
+	PresetSelectStage Stage = iota
+	AppSelectStage
 	OSSelectStage
 	EnvSelectStage
 	InputStage
@@ -39,11 +40,27 @@ const (
 )
 
 func (s Stage) String() string {
-	return [...]string{"AppSelect", "OSSelect", "EnvSelect", "InputStage", "ConfirmStage"}[s]
+	return [...]string{
+		"PresetSelect",
+		"AppSelect",
+		"OSSelect",
+		"EnvSelect",
+		"InputStage",
+		"ConfirmStage",
+		"GitActionStage",
+	}[s]
 }
 
 func (s Stage) OutPutString() string {
-	return [...]string{"Selected Apps", "Selected Platforms", "Selected Environments", "", ""}[s]
+	return [...]string{
+		"Selected Preset",
+		"Selected Apps",
+		"Selected Platforms",
+		"Selected Environments",
+		"",
+		"",
+		"",
+	}[s]
 }
 
 type DeploymentConfig struct {